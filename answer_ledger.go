@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Verdict is the server's judgement of a submitted answer
+type Verdict string
+
+const (
+	VerdictCorrect     Verdict = "correct"
+	VerdictIncorrect   Verdict = "incorrect"
+	VerdictRateLimited Verdict = "rate limited"
+	VerdictWrongLevel  Verdict = "wrong level"
+	VerdictUnknown     Verdict = "unknown"
+)
+
+// Hint is extra information the server gives about an incorrect answer
+type Hint string
+
+const (
+	HintNone    Hint = ""
+	HintTooHigh Hint = "too high"
+	HintTooLow  Hint = "too low"
+)
+
+// DefaultLedgerName is the name of the answer ledger file, kept in the input directory
+const DefaultLedgerName = "answers.json"
+
+// ErrKnownWrongAnswer is returned when an answer has already been submitted and judged incorrect
+var ErrKnownWrongAnswer = errors.New("answer is already known to be wrong")
+
+// ErrAnswerOutOfBounds is returned when an answer is outside the bounds given by earlier too high/too low hints
+var ErrAnswerOutOfBounds = errors.New("answer is outside known bounds")
+
+// ErrAlreadySolved is returned when a different answer has already been recorded as correct
+var ErrAlreadySolved = errors.New("a different answer is already known to be correct")
+
+// LedgerEntry records a single answer submission
+type LedgerEntry struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Hint    Hint      `json:"hint,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// AnswerLedger is a local record of every answer submitted, stored as JSON
+type AnswerLedger struct {
+	path      string
+	Entries   []LedgerEntry `json:"entries"`
+	NotBefore time.Time     `json:"not_before,omitempty"`
+}
+
+// DefaultLedgerPath returns the default location of the answer ledger, in the current locator's input directory.
+// It is an error if the locator reads from an fs.FS, which has no directory to keep the ledger in.
+func DefaultLedgerPath() (string, error) {
+	dir, err := GetInputLocator().Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, DefaultLedgerName), nil
+}
+
+// LoadAnswerLedger reads a ledger from disk.  If the file does not exist, an empty ledger is returned.
+func LoadAnswerLedger(path string) (*AnswerLedger, error) {
+	l := &AnswerLedger{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, l)
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Save writes the ledger back to the file it was loaded from
+func (l *AnswerLedger) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(l.path, append(data, '\n'))
+}
+
+// Record adds an entry to the ledger
+func (l *AnswerLedger) Record(e LedgerEntry) {
+	l.Entries = append(l.Entries, e)
+}
+
+// Attempts returns all the entries for a given puzzle part
+func (l *AnswerLedger) Attempts(year, day, part int) []LedgerEntry {
+	var results []LedgerEntry
+	for _, e := range l.Entries {
+		if e.Year == year && e.Day == day && e.Part == part {
+			results = append(results, e)
+		}
+	}
+	return results
+}
+
+// CorrectAnswer returns the answer recorded as correct for a given puzzle part.  The bool is returned false if
+// there is none.
+func (l *AnswerLedger) CorrectAnswer(year, day, part int) (string, bool) {
+	for _, e := range l.Attempts(year, day, part) {
+		if e.Verdict == VerdictCorrect {
+			return e.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Check returns an error if submitting an answer would be pointless, based on what is already in the ledger.  The
+// ledger must already be loaded; with the default path, that fails for locators that read from an fs.FS, so use
+// WithLedgerPath or LoadAnswerLedger with an explicit path in that case.
+func (l *AnswerLedger) Check(year, day, part int, answer string) error {
+	if correct, ok := l.CorrectAnswer(year, day, part); ok && correct != answer {
+		return fmt.Errorf("%w: %s", ErrAlreadySolved, correct)
+	}
+	ans, isNum := new(big.Int).SetString(answer, 10)
+	for _, e := range l.Attempts(year, day, part) {
+		if e.Verdict != VerdictIncorrect {
+			continue
+		}
+		if e.Answer == answer {
+			return fmt.Errorf("%w: %s", ErrKnownWrongAnswer, answer)
+		}
+		if !isNum {
+			continue
+		}
+		bound, ok := new(big.Int).SetString(e.Answer, 10)
+		if !ok {
+			continue
+		}
+		if (e.Hint == HintTooHigh && ans.Cmp(bound) >= 0) || (e.Hint == HintTooLow && ans.Cmp(bound) <= 0) {
+			return fmt.Errorf("%w: %s was %s", ErrAnswerOutOfBounds, e.Answer, e.Hint)
+		}
+	}
+	return nil
+}
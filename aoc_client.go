@@ -0,0 +1,224 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// AocClient talks to the Advent of Code website, or to anything serving the same endpoints
+type AocClient struct {
+	AocClientOptions
+	lock       sync.Mutex
+	inFlight   map[string]*sync.Mutex
+	submitLock sync.Mutex
+}
+
+// AocClientOptions collects extra options when initializing an AocClient
+type AocClientOptions struct {
+	baseURL       string
+	session       string
+	sessionFile   string
+	httpClient    *http.Client
+	userAgent     string
+	ledgerPath    string
+	rateLimitWait bool
+}
+
+// DefaultAocBaseURL is the base URL of the Advent of Code website
+const DefaultAocBaseURL = "https://adventofcode.com"
+
+// DefaultSessionEnvVar is the environment variable consulted for the session token
+const DefaultSessionEnvVar = "AOC_SESSION"
+
+// ErrNoSession is returned when no session token can be found
+var ErrNoSession = errors.New("no session token found")
+
+// ErrInvalidSession is returned when the server rejects the session token
+var ErrInvalidSession = errors.New("session token rejected by server")
+
+// ErrInputNotUnlocked is returned when the requested puzzle has not been unlocked yet
+var ErrInputNotUnlocked = errors.New("puzzle not unlocked yet")
+
+// WithBaseURL sets the base URL of the server, such as an httptest.Server URL
+func WithBaseURL(baseURL string) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithSession provides the session token directly, overriding the environment and config file
+func WithSession(session string) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.session = session
+	}
+}
+
+// WithSessionFile sets the config file the session token is read from, if it is not in the environment
+func WithSessionFile(path string) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.sessionFile = path
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(client *http.Client) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.httpClient = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with requests
+func WithUserAgent(userAgent string) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.userAgent = userAgent
+	}
+}
+
+// NewAocClient allocates and initializes a new AocClient
+func NewAocClient(options ...func(*AocClientOptions)) *AocClient {
+	c := AocClient{
+		AocClientOptions: AocClientOptions{
+			baseURL:    DefaultAocBaseURL,
+			httpClient: http.DefaultClient,
+			userAgent:  "github.com/ghjm/advent_utils",
+		},
+		inFlight: make(map[string]*sync.Mutex),
+	}
+	for _, opt := range options {
+		opt(&c.AocClientOptions)
+	}
+	return &c
+}
+
+// DefaultSessionFile returns the default location of the session token config file
+func DefaultSessionFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "advent_utils", "session"), nil
+}
+
+// Session returns the session token, from the options, the environment or the config file, in that order
+func (c *AocClient) Session() (string, error) {
+	if c.session != "" {
+		return c.session, nil
+	}
+	if s := strings.TrimSpace(os.Getenv(DefaultSessionEnvVar)); s != "" {
+		return s, nil
+	}
+	fn := c.sessionFile
+	if fn == "" {
+		var err error
+		fn, err = DefaultSessionFile()
+		if err != nil {
+			return "", err
+		}
+	}
+	data, err := os.ReadFile(fn)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: set %s or create %s", ErrNoSession, DefaultSessionEnvVar, fn)
+	} else if err != nil {
+		return "", err
+	}
+	s := strings.TrimSpace(string(data))
+	if s == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNoSession, fn)
+	}
+	return s, nil
+}
+
+// newRequest creates a request to the server with the session cookie attached
+func (c *AocClient) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	session, err := c.Session()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: session})
+	req.Header.Set("User-Agent", c.userAgent)
+	return req, nil
+}
+
+// destLock returns a lock that is held while a given destination file is being downloaded
+func (c *AocClient) destLock(dest string) *sync.Mutex {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	m, ok := c.inFlight[dest]
+	if !ok {
+		m = &sync.Mutex{}
+		c.inFlight[dest] = m
+	}
+	return m
+}
+
+// FetchInput downloads the input for a given year and day into dest.  If dest already exists, nothing is downloaded.
+func (c *AocClient) FetchInput(year, day int, dest string) error {
+	m := c.destLock(dest)
+	m.Lock()
+	defer m.Unlock()
+	if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return fmt.Errorf("fetching %d day %d: %w", year, day, ErrInvalidSession)
+	case http.StatusNotFound:
+		return fmt.Errorf("fetching %d day %d: %w", year, day, ErrInputNotUnlocked)
+	default:
+		return fmt.Errorf("fetching %d day %d: unexpected status %s", year, day, resp.Status)
+	}
+	return writeFileAtomic(dest, data)
+}
+
+// writeFileAtomic writes a file by way of a temporary file in the same directory, so readers never see a partial file
+func writeFileAtomic(dest string, data []byte) error {
+	dir := filepath.Dir(dest)
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Close()
+	} else {
+		_ = f.Close()
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), dest)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// testInputServer serves a fixed puzzle input, counting the requests it receives
+func testInputServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		if r.URL.Path != "/2023/day/1/input" {
+			http.NotFound(w, r)
+			return
+		}
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != "test-session" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &count
+}
+
+func TestSessionFromEnv(t *testing.T) {
+	t.Setenv(DefaultSessionEnvVar, " env-session\n")
+	c := NewAocClient(WithSessionFile(filepath.Join(t.TempDir(), "missing")))
+	s, err := c.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "env-session" {
+		t.Errorf("expected env-session, got %q", s)
+	}
+}
+
+func TestSessionFromFile(t *testing.T) {
+	t.Setenv(DefaultSessionEnvVar, "")
+	fn := filepath.Join(t.TempDir(), "session")
+	err := os.WriteFile(fn, []byte("file-session\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewAocClient(WithSessionFile(fn))
+	s, err := c.Session()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "file-session" {
+		t.Errorf("expected file-session, got %q", s)
+	}
+}
+
+func TestSessionMissing(t *testing.T) {
+	t.Setenv(DefaultSessionEnvVar, "")
+	c := NewAocClient(WithSessionFile(filepath.Join(t.TempDir(), "missing")))
+	_, err := c.Session()
+	if !errors.Is(err, ErrNoSession) {
+		t.Errorf("expected ErrNoSession, got %v", err)
+	}
+}
+
+func TestFetchInput(t *testing.T) {
+	srv, count := testInputServer(t, http.StatusOK, "1\n2\n3\n")
+	c := NewAocClient(WithBaseURL(srv.URL), WithSession("test-session"))
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "2023", "day01.txt")
+	err := c.FetchInput(2023, 1, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "1\n2\n3\n" {
+		t.Errorf("unexpected input %q", data)
+	}
+	entries, err := os.ReadDir(filepath.Dir(dest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the input file, found %d entries", len(entries))
+	}
+	err = c.FetchInput(2023, 1, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		status  int
+		wantErr error
+	}{
+		{"invalid session", "bad-session", http.StatusOK, ErrInvalidSession},
+		{"not unlocked", "test-session", http.StatusNotFound, ErrInputNotUnlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := testInputServer(t, tt.status, "")
+			c := NewAocClient(WithBaseURL(srv.URL), WithSession(tt.session))
+			dest := filepath.Join(t.TempDir(), "day01.txt")
+			err := c.FetchInput(2023, 1, dest)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if _, err := os.Stat(dest); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("expected no input file to be written")
+			}
+		})
+	}
+}
+
+func TestInputLocatorFetchesMissingDay(t *testing.T) {
+	srv, count := testInputServer(t, http.StatusOK, "fetched\n")
+	c := NewAocClient(WithBaseURL(srv.URL), WithSession("test-session"))
+	l := NewInputLocator(WithInputEnvVar(""), WithInputDir(t.TempDir()), WithFetcher(c))
+	for range 2 {
+		f, err := l.Open(l.DayName(2023, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "fetched\n" {
+			t.Errorf("unexpected input %q", data)
+		}
+	}
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	f, err := l.Open("notes.txt")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a non-day input to be reported missing, got %v", err)
+	}
+	if f != nil {
+		t.Errorf("expected a nil file, got %#v", f)
+	}
+}
@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SubmitResult is the outcome of submitting an answer
+type SubmitResult struct {
+	Verdict Verdict
+	Hint    Hint
+	Wait    time.Duration
+	Message string
+}
+
+// RateLimitError is returned when an answer cannot be submitted yet because of the server's rate limit
+type RateLimitError struct {
+	Wait time.Duration
+}
+
+// Error returns the error message
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: %s left to wait", e.Wait.Round(time.Second))
+}
+
+// WithLedgerPath sets the path of the answer ledger.  By default it is kept in the input directory.
+func WithLedgerPath(path string) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.ledgerPath = path
+	}
+}
+
+// WithRateLimitWait makes SubmitAnswer sleep until the server's wait time has passed, instead of returning
+// a RateLimitError
+func WithRateLimitWait(wait bool) func(*AocClientOptions) {
+	return func(options *AocClientOptions) {
+		options.rateLimitWait = wait
+	}
+}
+
+// LedgerPath returns the path of the answer ledger.  Unless WithLedgerPath was given, this is DefaultLedgerPath,
+// which fails for locators that read from an fs.FS.
+func (c *AocClient) LedgerPath() (string, error) {
+	if c.ledgerPath != "" {
+		return c.ledgerPath, nil
+	}
+	return DefaultLedgerPath()
+}
+
+// LoadLedger loads the answer ledger used by this client
+func (c *AocClient) LoadLedger() (*AnswerLedger, error) {
+	path, err := c.LedgerPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadAnswerLedger(path)
+}
+
+// SubmitAnswer submits an answer for one part of a puzzle and records the result in the ledger.  Answers that the
+// ledger shows cannot be right are refused without contacting the server.
+func (c *AocClient) SubmitAnswer(year, day, part int, answer any) (*SubmitResult, error) {
+	c.submitLock.Lock()
+	defer c.submitLock.Unlock()
+	ans := strings.TrimSpace(fmt.Sprint(answer))
+	if ans == "" {
+		return nil, fmt.Errorf("empty answer")
+	}
+	ledger, err := c.LoadLedger()
+	if err != nil {
+		return nil, err
+	}
+	if correct, ok := ledger.CorrectAnswer(year, day, part); ok && correct == ans {
+		return &SubmitResult{Verdict: VerdictCorrect, Message: "answer already recorded as correct"}, nil
+	}
+	err = ledger.Check(year, day, part, ans)
+	if err != nil {
+		return nil, err
+	}
+	if wait := time.Until(ledger.NotBefore); wait > 0 {
+		if !c.rateLimitWait {
+			return nil, &RateLimitError{Wait: wait}
+		}
+		time.Sleep(wait)
+	}
+	form := url.Values{}
+	form.Set("level", strconv.Itoa(part))
+	form.Set("answer", ans)
+	req, err := c.newRequest(http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("submitting %d day %d part %d: %w", year, day, part, ErrInvalidSession)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("submitting %d day %d part %d: %w", year, day, part, ErrInputNotUnlocked)
+	default:
+		return nil, fmt.Errorf("submitting %d day %d part %d: unexpected status %s", year, day, part, resp.Status)
+	}
+	result := parseSubmitResponse(string(body))
+	now := time.Now()
+	if result.Wait > 0 {
+		ledger.NotBefore = now.Add(result.Wait)
+	}
+	if result.Verdict == VerdictCorrect || result.Verdict == VerdictIncorrect {
+		ledger.Record(LedgerEntry{
+			Year:    year,
+			Day:     day,
+			Part:    part,
+			Answer:  ans,
+			Verdict: result.Verdict,
+			Hint:    result.Hint,
+			Time:    now,
+		})
+	}
+	err = ledger.Save()
+	if err != nil {
+		return nil, err
+	}
+	if result.Verdict == VerdictRateLimited {
+		return result, &RateLimitError{Wait: result.Wait}
+	}
+	return result, nil
+}
+
+var (
+	articleRegex   = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex       = regexp.MustCompile(`<[^>]*>`)
+	leftToWait     = regexp.MustCompile(`(?:(\d+)m\s*)?(\d+)s left to wait`)
+	waitMinutes    = regexp.MustCompile(`(?i)wait (\w+) minutes?`)
+	minuteWordVals = map[string]int{
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	}
+)
+
+// parseSubmitResponse extracts the verdict, hint and wait time from the server's response page
+func parseSubmitResponse(body string) *SubmitResult {
+	msg := body
+	if m := articleRegex.FindStringSubmatch(body); m != nil {
+		msg = m[1]
+	}
+	msg = strings.Join(strings.Fields(tagRegex.ReplaceAllString(msg, "")), " ")
+	result := &SubmitResult{Verdict: VerdictUnknown, Message: msg}
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		result.Verdict = VerdictCorrect
+	case strings.Contains(msg, "That's not the right answer"):
+		result.Verdict = VerdictIncorrect
+		if strings.Contains(msg, "too high") {
+			result.Hint = HintTooHigh
+		} else if strings.Contains(msg, "too low") {
+			result.Hint = HintTooLow
+		}
+	case strings.Contains(msg, "answer too recently"):
+		result.Verdict = VerdictRateLimited
+	case strings.Contains(msg, "solving the right level"):
+		result.Verdict = VerdictWrongLevel
+	}
+	if m := leftToWait.FindStringSubmatch(msg); m != nil {
+		mins, _ := strconv.Atoi(m[1])
+		secs, _ := strconv.Atoi(m[2])
+		result.Wait = time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second
+	} else if m := waitMinutes.FindStringSubmatch(msg); m != nil {
+		mins, ok := minuteWordVals[strings.ToLower(m[1])]
+		if !ok {
+			mins, _ = strconv.Atoi(m[1])
+		}
+		result.Wait = time.Duration(mins) * time.Minute
+	}
+	return result
+}
@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// testAnswerServer responds to every answer submission with a fixed page, counting the requests it receives
+func testAnswerServer(t *testing.T, page string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/1/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("<html><body><main><article><p>" + page + "</p></article></main></body></html>"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &count
+}
+
+// testLedgerClient returns a client talking to a server, with its ledger preloaded with some entries
+func testLedgerClient(t *testing.T, srv *httptest.Server, entries ...LedgerEntry) *AocClient {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultLedgerName)
+	l, err := LoadAnswerLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		l.Record(e)
+	}
+	err = l.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewAocClient(WithBaseURL(srv.URL), WithSession("test-session"), WithLedgerPath(path))
+}
+
+func TestSubmitRefusesKnownAnswers(t *testing.T) {
+	srv, count := testAnswerServer(t, "That's the right answer!")
+	c := testLedgerClient(t, srv,
+		LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "42", Verdict: VerdictIncorrect},
+		LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "100", Verdict: VerdictIncorrect, Hint: HintTooHigh},
+		LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "10", Verdict: VerdictIncorrect, Hint: HintTooLow},
+	)
+	tests := []struct {
+		answer  any
+		wantErr error
+	}{
+		{42, ErrKnownWrongAnswer},
+		{100, ErrKnownWrongAnswer},
+		{150, ErrAnswerOutOfBounds},
+		{"123456789012345678901234567890", ErrAnswerOutOfBounds},
+		{10, ErrKnownWrongAnswer},
+		{5, ErrAnswerOutOfBounds},
+	}
+	for _, tt := range tests {
+		_, err := c.SubmitAnswer(2023, 1, 1, tt.answer)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("submitting %v: expected %v, got %v", tt.answer, tt.wantErr, err)
+		}
+	}
+	if n := count.Load(); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+	result, err := c.SubmitAnswer(2023, 1, 1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verdict != VerdictCorrect {
+		t.Errorf("expected a correct verdict, got %q", result.Verdict)
+	}
+	_, err = c.SubmitAnswer(2023, 1, 1, 51)
+	if !errors.Is(err, ErrAlreadySolved) {
+		t.Errorf("expected ErrAlreadySolved, got %v", err)
+	}
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestSubmitRateLimited(t *testing.T) {
+	srv, count := testAnswerServer(t, "You gave an answer too recently; you have to wait after submitting an answer "+
+		"before trying again. You have 5m 3s left to wait.")
+	c := testLedgerClient(t, srv)
+	result, err := c.SubmitAnswer(2023, 1, 1, 42)
+	var rle *RateLimitError
+	if !errors.As(err, &rle) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if rle.Wait != 5*time.Minute+3*time.Second {
+		t.Errorf("expected a wait of 5m3s, got %s", rle.Wait)
+	}
+	if result == nil || result.Verdict != VerdictRateLimited {
+		t.Errorf("expected a rate limited verdict, got %+v", result)
+	}
+	_, err = c.SubmitAnswer(2023, 1, 1, 43)
+	if !errors.As(err, &rle) {
+		t.Fatalf("expected a RateLimitError from the ledger, got %v", err)
+	}
+	if n := count.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	l, err := c.LoadLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Entries) != 0 {
+		t.Errorf("expected rate limited answers not to be recorded, got %d entries", len(l.Entries))
+	}
+}
+
+func TestParseSubmitResponse(t *testing.T) {
+	tests := []struct {
+		page    string
+		verdict Verdict
+		hint    Hint
+		wait    time.Duration
+	}{
+		{
+			page:    "<article><p>That's the right answer! You are <em>one gold star</em> closer.</p></article>",
+			verdict: VerdictCorrect,
+		},
+		{
+			page: "<article><p>That's not the right answer; your answer is too high. Please wait one minute " +
+				"before trying again.</p></article>",
+			verdict: VerdictIncorrect,
+			hint:    HintTooHigh,
+			wait:    time.Minute,
+		},
+		{
+			page: "<article><p>That's not the right answer; your answer is too low. Please wait five minutes " +
+				"before trying again.</p></article>",
+			verdict: VerdictIncorrect,
+			hint:    HintTooLow,
+			wait:    5 * time.Minute,
+		},
+		{
+			page:    "<article><p>You gave an answer too recently. You have 5m 3s left to wait.</p></article>",
+			verdict: VerdictRateLimited,
+			wait:    5*time.Minute + 3*time.Second,
+		},
+		{
+			page:    "<article><p>You gave an answer too recently. You have 27s left to wait.</p></article>",
+			verdict: VerdictRateLimited,
+			wait:    27 * time.Second,
+		},
+		{
+			page:    "<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>",
+			verdict: VerdictWrongLevel,
+		},
+	}
+	for _, tt := range tests {
+		r := parseSubmitResponse(tt.page)
+		if r.Verdict != tt.verdict || r.Hint != tt.hint || r.Wait != tt.wait {
+			t.Errorf("parsing %q: expected %q/%q/%s, got %q/%q/%s", tt.page, tt.verdict, tt.hint, tt.wait,
+				r.Verdict, r.Hint, r.Wait)
+		}
+	}
+}
+
+func TestAnswerLedgerRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", DefaultLedgerName)
+	l, err := LoadAnswerLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Entries) != 0 {
+		t.Fatalf("expected a missing ledger to be empty")
+	}
+	now := time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)
+	l.Record(LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "100", Verdict: VerdictIncorrect, Hint: HintTooHigh, Time: now})
+	l.Record(LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "54", Verdict: VerdictCorrect, Time: now.Add(time.Minute)})
+	l.NotBefore = now.Add(2 * time.Minute)
+	err = l.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := LoadAnswerLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l2.Entries) != 2 || l2.Entries[0] != l.Entries[0] || l2.Entries[1] != l.Entries[1] {
+		t.Errorf("entries did not round trip: %+v", l2.Entries)
+	}
+	if !l2.NotBefore.Equal(l.NotBefore) {
+		t.Errorf("expected NotBefore %s, got %s", l.NotBefore, l2.NotBefore)
+	}
+	if ans, ok := l2.CorrectAnswer(2023, 1, 1); !ok || ans != "54" {
+		t.Errorf("expected correct answer 54, got %q", ans)
+	}
+}
+
+func TestLedgerPath(t *testing.T) {
+	old := GetInputLocator()
+	t.Cleanup(func() {
+		SetInputLocator(old)
+	})
+	dir := t.TempDir()
+	SetInputLocator(NewInputLocator(WithInputEnvVar(""), WithInputDir(dir)))
+	path, err := NewAocClient().LedgerPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(dir, DefaultLedgerName) {
+		t.Errorf("expected the ledger in the input directory, got %s", path)
+	}
+	SetInputLocator(NewInputLocator(WithInputFS(fstest.MapFS{})))
+	_, err = DefaultLedgerPath()
+	if err == nil {
+		t.Errorf("expected an error for a locator reading from an fs.FS")
+	}
+	path, err = NewAocClient(WithLedgerPath("answers.json")).LedgerPath()
+	if err != nil || path != "answers.json" {
+		t.Errorf("expected the explicit ledger path, got %q, %v", path, err)
+	}
+}
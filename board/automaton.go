@@ -0,0 +1,206 @@
+package board
+
+import (
+	"slices"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// Neighborhood selects which cells count as neighbors in an Automaton
+type Neighborhood int
+
+const (
+	// Moore is the eight surrounding cells, including diagonals
+	Moore Neighborhood = iota
+	// VonNeumann is the four cardinal cells
+	VonNeumann
+)
+
+// countRule is a birth/survive rule, as set by WithCountRule
+type countRule[VT any] struct {
+	alive   VT
+	birth   []int
+	survive []int
+}
+
+// AutomatonOptions collects options when initializing an Automaton
+type AutomatonOptions[KT constraints.Integer, VT any] struct {
+	neighborhood Neighborhood
+	transition   func(p utils.Point[KT], v VT, neighbors []VT) VT
+	countRule    *countRule[VT]
+	expand       bool
+}
+
+// WithNeighborhood sets the neighborhood.  The default is Moore.
+func WithNeighborhood[KT constraints.Integer, VT any](neighborhood Neighborhood) func(*AutomatonOptions[KT, VT]) {
+	return func(options *AutomatonOptions[KT, VT]) {
+		options.neighborhood = neighborhood
+	}
+}
+
+// WithTransition sets a custom rule, which is given a cell's position, value and neighbor values, and returns the
+// cell's next value.  The neighbors slice is reused between calls and must not be retained.
+func WithTransition[KT constraints.Integer, VT any](transition func(p utils.Point[KT], v VT, neighbors []VT) VT) func(*AutomatonOptions[KT, VT]) {
+	return func(options *AutomatonOptions[KT, VT]) {
+		options.transition = transition
+		options.countRule = nil
+	}
+}
+
+// WithCountRule sets a count-based rule.  Cells are either alive (equal to the alive value) or dead (anything
+// else).  A dead cell becomes alive if its number of live neighbors is in birth, and a live cell stays alive if
+// its number of live neighbors is in survive, otherwise becoming the board's empty value.  Conway's Game of Life
+// is birth [3], survive [2 3].
+func WithCountRule[KT constraints.Integer, VT any](alive VT, birth []int, survive []int) func(*AutomatonOptions[KT, VT]) {
+	return func(options *AutomatonOptions[KT, VT]) {
+		options.transition = nil
+		options.countRule = &countRule[VT]{
+			alive:   alive,
+			birth:   slices.Clone(birth),
+			survive: slices.Clone(survive),
+		}
+	}
+}
+
+// WithExpansion lets the pattern spread beyond the board's bounds, which grow to include any cell that changes.
+// Without it, cells outside the bounds are never changed.
+func WithExpansion[KT constraints.Integer, VT any]() func(*AutomatonOptions[KT, VT]) {
+	return func(options *AutomatonOptions[KT, VT]) {
+		options.expand = true
+	}
+}
+
+// Automaton steps a Board as a cellular automaton, updating it in place.  Only cells next to a change in the
+// previous generation are recomputed, so sparse or settling patterns are cheap to run.
+type Automaton[KT constraints.Integer, VT any] struct {
+	AutomatonOptions[KT, VT]
+	board      *Board[KT, VT]
+	dirty      map[utils.Point[KT]]struct{}
+	generation int
+}
+
+// NewAutomaton allocates and initializes a new Automaton running on a board.  A rule must be given using
+// WithCountRule or WithTransition.
+func NewAutomaton[KT constraints.Integer, VT any](b *Board[KT, VT], options ...func(*AutomatonOptions[KT, VT])) *Automaton[KT, VT] {
+	if b.compFunc == nil {
+		panic("compFunc not defined")
+	}
+	a := &Automaton[KT, VT]{
+		board: b,
+		dirty: make(map[utils.Point[KT]]struct{}),
+	}
+	for _, opt := range options {
+		opt(&a.AutomatonOptions)
+	}
+	if a.countRule != nil {
+		cr := a.countRule
+		a.transition = func(_ utils.Point[KT], v VT, neighbors []VT) VT {
+			count := 0
+			for _, nv := range neighbors {
+				if b.compFunc(nv, cr.alive) {
+					count++
+				}
+			}
+			if b.compFunc(v, cr.alive) {
+				if slices.Contains(cr.survive, count) {
+					return v
+				}
+				return b.emptyVal
+			}
+			if slices.Contains(cr.birth, count) {
+				return cr.alive
+			}
+			return v
+		}
+	}
+	if a.transition == nil {
+		panic("automaton rule not defined")
+	}
+	if a.expand {
+		for p := range b.Points() {
+			a.markDirty(p)
+		}
+	}
+	for p := range b.InBounds() {
+		a.dirty[p] = struct{}{}
+	}
+	return a
+}
+
+// Board returns the board the automaton is running on
+func (a *Automaton[KT, VT]) Board() *Board[KT, VT] {
+	return a.board
+}
+
+// Generation returns the number of steps run so far
+func (a *Automaton[KT, VT]) Generation() int {
+	return a.generation
+}
+
+// neighbors returns the neighbors of a point, according to the neighborhood
+func (a *Automaton[KT, VT]) neighbors(p utils.Point[KT]) []utils.Point[KT] {
+	if a.neighborhood == VonNeumann {
+		return a.board.Cardinals(p, a.expand)
+	}
+	return a.board.Diagonals(p, a.expand)
+}
+
+// markDirty marks a point and its neighbors for recomputation in the next step
+func (a *Automaton[KT, VT]) markDirty(p utils.Point[KT]) {
+	a.dirty[p] = struct{}{}
+	for _, np := range a.neighbors(p) {
+		a.dirty[np] = struct{}{}
+	}
+}
+
+// Step advances the board by one generation, and returns the number of cells that changed
+func (a *Automaton[KT, VT]) Step() int {
+	b := a.board
+	type change struct {
+		p utils.Point[KT]
+		v VT
+	}
+	var changes []change
+	var nvals []VT
+	for p := range a.dirty {
+		if !a.expand && !b.Contains(p) {
+			continue
+		}
+		nvals = nvals[:0]
+		for _, np := range a.neighbors(p) {
+			nvals = append(nvals, b.Get(np))
+		}
+		v := b.Get(p)
+		nv := a.transition(p, v, nvals)
+		if !b.compFunc(v, nv) {
+			changes = append(changes, change{p: p, v: nv})
+		}
+	}
+	clear(a.dirty)
+	for _, c := range changes {
+		if b.compFunc(c.v, b.emptyVal) {
+			b.Clear(c.p)
+		} else if a.expand {
+			b.SetAndExpandBounds(c.p, c.v)
+		} else {
+			b.Set(c.p, c.v)
+		}
+		a.markDirty(c.p)
+	}
+	a.generation++
+	return len(changes)
+}
+
+// StepN advances the board by n generations, stopping early if the board stops changing.  It returns the number
+// of generations that changed the board.
+func (a *Automaton[KT, VT]) StepN(n int) int {
+	for i := 0; i < n; i++ {
+		if a.Step() == 0 {
+			// nothing is dirty, so every remaining generation is the same
+			a.generation += n - i - 1
+			return i
+		}
+	}
+	return n
+}
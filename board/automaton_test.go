@@ -0,0 +1,141 @@
+package board
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// lifeByTransform advances a board by one generation of Life, recomputing every stored cell with Transform.  The
+// board must store its dead cells, so that every cell within the bounds is visited.
+func lifeByTransform(b *RuneBoard[int]) {
+	b.Transform(func(p utils.Point[int], v rune) rune {
+		n := 0
+		for _, np := range b.Diagonals(p, false) {
+			if b.Get(np) == '#' {
+				n++
+			}
+		}
+		if n == 3 || (v == '#' && n == 2) {
+			return '#'
+		}
+		return '.'
+	})
+}
+
+// liveCells returns the set of cells holding '#'
+func liveCells(b *Board[int, rune]) map[utils.Point[int]]struct{} {
+	cells := make(map[utils.Point[int]]struct{})
+	for p, v := range b.All() {
+		if v == '#' {
+			cells[p] = struct{}{}
+		}
+	}
+	return cells
+}
+
+// sameCells compares two sets of cells, the second shifted by an offset
+func sameCells(a, b map[utils.Point[int]]struct{}, offset int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for p := range b {
+		if _, ok := a[utils.Point[int]{X: p.X - offset, Y: p.Y - offset}]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAutomatonLifeBounded(t *testing.T) {
+	const size, generations = 20, 30
+	rng := rand.New(rand.NewSource(1))
+	lines := make([]string, size)
+	for y := range lines {
+		var sb strings.Builder
+		for range size {
+			if rng.Intn(3) == 0 {
+				sb.WriteRune('#')
+			} else {
+				sb.WriteRune('.')
+			}
+		}
+		lines[y] = sb.String()
+	}
+	b := NewRuneBoard[int]()
+	b.MustFromStrings(lines)
+	ref := NewRuneBoard[int](WithEmptyVal[int, rune](' '))
+	ref.MustFromStrings(lines)
+	a := NewAutomaton(&b.Board, WithCountRule[int, rune]('#', []int{3}, []int{2, 3}))
+	for gen := 1; gen <= generations; gen++ {
+		a.StepN(1)
+		lifeByTransform(ref)
+		if !sameCells(liveCells(&b.Board), liveCells(&ref.Board), 0) {
+			t.Fatalf("generation %d: expected\n%s\ngot\n%s", gen, strings.Join(ref.Format(), "\n"),
+				strings.Join(b.Format(), "\n"))
+		}
+	}
+	if a.Generation() != generations {
+		t.Errorf("expected generation %d, got %d", generations, a.Generation())
+	}
+	if b.Bounds() != (utils.Rectangle[int]{P2: utils.Point[int]{X: size - 1, Y: size - 1}}) {
+		t.Errorf("expected the bounds not to change, got %v", b.Bounds())
+	}
+}
+
+func TestAutomatonLifeExpanding(t *testing.T) {
+	const generations = 30
+	// a glider heading down and to the right, and an R-pentomino
+	lines := []string{
+		".#....##",
+		"..#..##.",
+		"###...#.",
+	}
+	b := NewRuneBoard[int]()
+	b.MustFromStrings(lines)
+	a := NewAutomaton(&b.Board, WithCountRule[int, rune]('#', []int{3}, []int{2, 3}), WithExpansion[int, rune]())
+	if n := a.StepN(generations); n != generations {
+		t.Errorf("expected every generation to change the board, got %d", n)
+	}
+
+	// the reference board is padded so that nothing can reach its edges in time to be affected by them
+	const margin = generations + 2
+	padded := make([]string, 0, len(lines)+2*margin)
+	blank := strings.Repeat(".", len(lines[0])+2*margin)
+	for range margin {
+		padded = append(padded, blank)
+	}
+	for _, l := range lines {
+		padded = append(padded, strings.Repeat(".", margin)+l+strings.Repeat(".", margin))
+	}
+	for range margin {
+		padded = append(padded, blank)
+	}
+	ref := NewRuneBoard[int](WithEmptyVal[int, rune](' '))
+	ref.MustFromStrings(padded)
+	for range generations {
+		lifeByTransform(ref)
+	}
+	if !sameCells(liveCells(&b.Board), liveCells(&ref.Board), margin) {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(ref.Format(), "\n"), strings.Join(b.Format(), "\n"))
+	}
+	r := b.Bounds()
+	for p := range liveCells(&b.Board) {
+		if !p.Within(r) {
+			t.Errorf("expected the bounds %v to grow to include %v", r, p)
+		}
+	}
+	if r.P1.Y >= 0 || r.P2.X <= 7 || r.P2.Y <= 2 {
+		t.Errorf("expected the bounds to grow beyond the starting board, got %v", r)
+	}
+
+	still := NewRuneBoard[int]()
+	still.MustFromStrings([]string{"##", "##"})
+	a = NewAutomaton(&still.Board, WithCountRule[int, rune]('#', []int{3}, []int{2, 3}), WithExpansion[int, rune]())
+	if n := a.StepN(10); n != 0 || a.Generation() != 10 {
+		t.Errorf("expected a still life to stop early, got %d changing generations and generation %d", n,
+			a.Generation())
+	}
+}
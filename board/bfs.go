@@ -0,0 +1,153 @@
+package board
+
+import (
+	"slices"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// BFSOptions collects options for BFS
+type BFSOptions[KT constraints.Integer] struct {
+	diagonal    bool
+	goal        func(p utils.Point[KT]) bool
+	maxDistance int
+}
+
+// WithDiagonalMoves allows moves to all eight neighbors, instead of only the four cardinal ones
+func WithDiagonalMoves[KT constraints.Integer]() func(*BFSOptions[KT]) {
+	return func(options *BFSOptions[KT]) {
+		options.diagonal = true
+	}
+}
+
+// WithGoal stops the search as soon as a point satisfying the goal function is reached
+func WithGoal[KT constraints.Integer](goal func(p utils.Point[KT]) bool) func(*BFSOptions[KT]) {
+	return func(options *BFSOptions[KT]) {
+		options.goal = goal
+	}
+}
+
+// WithMaxDistance stops the search from going further than a given distance from the start, which is needed
+// on a tiled board
+func WithMaxDistance[KT constraints.Integer](maxDistance int) func(*BFSOptions[KT]) {
+	return func(options *BFSOptions[KT]) {
+		options.maxDistance = maxDistance
+	}
+}
+
+// BFSResult is the result of a breadth-first search
+type BFSResult[KT constraints.Integer] struct {
+	// Dist is the distance of each reached point from the nearest start
+	Dist map[utils.Point[KT]]int
+	// Parent is the point each reached point was first reached from.  Start points have no parent.
+	Parent map[utils.Point[KT]]utils.Point[KT]
+	// Goal is the goal point that stopped the search, if Found is true
+	Goal     utils.Point[KT]
+	Found    bool
+	diagonal bool
+}
+
+// BFS performs a breadth-first search from one or more start points, moving only onto points where passable
+// returns true.  The start points are always included, at distance 0.
+func (b *Board[KT, VT]) BFS(starts []utils.Point[KT], passable func(p utils.Point[KT], v VT) bool, options ...func(*BFSOptions[KT])) *BFSResult[KT] {
+	var opts BFSOptions[KT]
+	for _, opt := range options {
+		opt(&opts)
+	}
+	result := &BFSResult[KT]{
+		Dist:     make(map[utils.Point[KT]]int),
+		Parent:   make(map[utils.Point[KT]]utils.Point[KT]),
+		diagonal: opts.diagonal,
+	}
+	var queue []utils.Point[KT]
+	for _, s := range starts {
+		s = b.neighbor(s)
+		if _, ok := result.Dist[s]; ok {
+			continue
+		}
+		result.Dist[s] = 0
+		queue = append(queue, s)
+	}
+	for head := 0; head < len(queue); head++ {
+		cur := queue[head]
+		if opts.goal != nil && opts.goal(cur) {
+			result.Goal = cur
+			result.Found = true
+			break
+		}
+		d := result.Dist[cur]
+		if opts.maxDistance > 0 && d >= opts.maxDistance {
+			continue
+		}
+		var neighbors []utils.Point[KT]
+		if opts.diagonal {
+			neighbors = b.Diagonals(cur, false)
+		} else {
+			neighbors = b.Cardinals(cur, false)
+		}
+		for _, np := range neighbors {
+			if _, ok := result.Dist[np]; ok {
+				continue
+			}
+			if !passable(np, b.Get(np)) {
+				continue
+			}
+			result.Dist[np] = d + 1
+			result.Parent[np] = cur
+			queue = append(queue, np)
+		}
+	}
+	return result
+}
+
+// Path returns the shortest path from a start point to a given point, including both ends, or nil if the point
+// was not reached
+func (r *BFSResult[KT]) Path(to utils.Point[KT]) []utils.Point[KT] {
+	if _, ok := r.Dist[to]; !ok {
+		return nil
+	}
+	path := []utils.Point[KT]{to}
+	for {
+		p, ok := r.Parent[to]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+		to = p
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// ReachableInExactly returns the number of points where a walk of exactly n steps can end, when moves may be
+// undone.  This is every point at a distance of at most n with the same parity as n.  That only holds for cardinal
+// moves, so it panics if the search used WithDiagonalMoves.
+func (r *BFSResult[KT]) ReachableInExactly(n int) int {
+	if r.diagonal {
+		panic("ReachableInExactly relies on the parity of cardinal moves, and the search used diagonal moves")
+	}
+	count := 0
+	for _, d := range r.Dist {
+		if d <= n && d%2 == n%2 {
+			count++
+		}
+	}
+	return count
+}
+
+// DistanceBoard returns the distances as a board backed by DenseStorage, with bounds covering every reached
+// point.  Points that were not reached hold -1.
+func (r *BFSResult[KT]) DistanceBoard() *Board[KT, int] {
+	db := NewBoard[KT, int](
+		WithDenseStorage[KT, int](),
+		WithEmptyVal[KT, int](-1),
+		WithCompareFunc[KT, int](func(a, b int) bool {
+			return a == b
+		}),
+	)
+	for p, d := range r.Dist {
+		db.SetAndExpandBounds(p, d)
+	}
+	return db
+}
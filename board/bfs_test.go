@@ -0,0 +1,104 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// garden is the example map from Advent of Code 2023 day 21
+var garden = []string{
+	"...........",
+	".....###.#.",
+	".###.##..#.",
+	"..#.#...#..",
+	"....#.#....",
+	".##..S####.",
+	".##..#...#.",
+	".......##..",
+	".##.#.####.",
+	".##..##.##.",
+	"...........",
+}
+
+// notRock is a passable function for the garden
+func notRock(_ utils.Point[int], v rune) bool {
+	return v != '#'
+}
+
+func TestBFSPath(t *testing.T) {
+	b := NewRuneBoard[int]()
+	b.MustFromStrings([]string{
+		"S.#....",
+		".##.##.",
+		"....#E.",
+		"##.##..",
+		"......#",
+	})
+	start := utils.Point[int]{X: 0, Y: 0}
+	end := utils.Point[int]{X: 5, Y: 2}
+	r := b.BFS([]utils.Point[int]{start}, notRock)
+	path := r.Path(end)
+	if len(path) != r.Dist[end]+1 || r.Dist[end] != 11 {
+		t.Fatalf("expected a path of 11 steps, got distance %d and path %v", r.Dist[end], path)
+	}
+	if path[0] != start || path[len(path)-1] != end {
+		t.Errorf("expected the path to run from %v to %v, got %v", start, end, path)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].ManhattanDistance(path[i-1]) != 1 || b.Get(path[i]) == '#' {
+			t.Errorf("invalid step from %v to %v", path[i-1], path[i])
+		}
+	}
+	if p := r.Path(utils.Point[int]{X: 2, Y: 0}); p != nil {
+		t.Errorf("expected no path onto a wall, got %v", p)
+	}
+	if p := r.Path(start); len(p) != 1 {
+		t.Errorf("expected the path to a start point to be just that point, got %v", p)
+	}
+
+	r = b.BFS([]utils.Point[int]{start}, notRock, WithGoal(func(p utils.Point[int]) bool {
+		return b.Get(p) == 'E'
+	}))
+	if !r.Found || r.Goal != end || len(r.Path(end)) != 12 {
+		t.Errorf("expected the goal to be found at %v, got %+v", end, r)
+	}
+	r = b.BFS([]utils.Point[int]{start, {X: 6, Y: 0}}, notRock)
+	if r.Dist[end] != 3 {
+		t.Errorf("expected the distance from the nearest start to be 3, got %d", r.Dist[end])
+	}
+	r = b.BFS([]utils.Point[int]{start}, notRock, WithDiagonalMoves[int]())
+	if r.Dist[end] != 7 {
+		t.Errorf("expected diagonal moves to give a distance of 7, got %d", r.Dist[end])
+	}
+}
+
+func TestReachableInExactly(t *testing.T) {
+	b := NewRuneBoard[int]()
+	b.MustFromStrings(garden)
+	start := utils.Point[int]{X: 5, Y: 5}
+	r := b.BFS([]utils.Point[int]{start}, notRock)
+	if got := r.ReachableInExactly(6); got != 16 {
+		t.Errorf("expected 16 plots in exactly 6 steps, got %d", got)
+	}
+	if got := r.ReachableInExactly(0); got != 1 {
+		t.Errorf("expected only the start in 0 steps, got %d", got)
+	}
+
+	tiled := NewRuneBoard[int](WithTiling[int, rune]())
+	tiled.MustFromStrings(garden)
+	for _, tt := range []struct{ steps, want int }{{6, 16}, {10, 50}, {50, 1594}, {100, 6536}} {
+		r := tiled.BFS([]utils.Point[int]{start}, notRock, WithMaxDistance[int](tt.steps))
+		if got := r.ReachableInExactly(tt.steps); got != tt.want {
+			t.Errorf("expected %d plots in exactly %d steps on the tiled map, got %d", tt.want, tt.steps, got)
+		}
+	}
+
+	r = b.BFS([]utils.Point[int]{start}, notRock, WithDiagonalMoves[int]())
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected ReachableInExactly to panic after a search with diagonal moves")
+		}
+	}()
+	r.ReachableInExactly(6)
+}
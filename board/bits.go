@@ -0,0 +1,387 @@
+package board
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"runtime"
+	"sync"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// BitStorage is a BoardStorage for boolean boards, storing one bit per cell in packed uint64 rows.  A cell is
+// present exactly when it is true, so setting a cell to false deletes it.  Like DenseStorage, the allocated area
+// has an arbitrary origin and grows automatically when a point outside it is set to true.
+type BitStorage[KT constraints.Integer] struct {
+	words        []uint64
+	origin       utils.Point[KT]
+	width        int
+	height       int
+	wordsPerRow  int
+	lastWordMask uint64
+}
+
+// BitRow is a view of one row of a BitStorage, where bit i represents the cell at X = origin.X + i.  Operations
+// on a BitRow modify the underlying storage.
+type BitRow struct {
+	words        []uint64
+	width        int
+	lastWordMask uint64
+}
+
+// NewBitStorage allocates and initializes a new BitStorage
+func NewBitStorage[KT constraints.Integer]() *BitStorage[KT] {
+	return &BitStorage[KT]{}
+}
+
+// WithBitStorage provides a new BitStorage backend to a boolean Board.  Each board the option is applied to gets
+// its own storage.
+func WithBitStorage[KT constraints.Integer]() func(*BoardOptions[KT, bool]) {
+	return func(options *BoardOptions[KT, bool]) {
+		options.storage = NewBitStorage[KT]()
+	}
+}
+
+// Allocate discards all data and allocates an area of the given size, with its origin at (0, 0)
+func (bs *BitStorage[KT]) Allocate(width, height KT, _ bool) {
+	bs.allocate(int(width), int(height))
+}
+
+// allocate is Allocate with int sizes, so that an area covering most of a small KT's range can be described
+func (bs *BitStorage[KT]) allocate(width, height int) {
+	bs.origin = utils.Point[KT]{}
+	bs.width = width
+	bs.height = height
+	bs.wordsPerRow = (bs.width + 63) / 64
+	bs.words = make([]uint64, bs.wordsPerRow*bs.height)
+	bs.lastWordMask = ^uint64(0)
+	if bs.width%64 != 0 {
+		bs.lastWordMask = (1 << (bs.width % 64)) - 1
+	}
+}
+
+// locate returns the word index and bit of a point.  The bool is returned false if the point is outside the
+// allocation.
+func (bs *BitStorage[KT]) locate(p utils.Point[KT]) (int, uint64, bool) {
+	x := int(p.X) - int(bs.origin.X)
+	y := int(p.Y) - int(bs.origin.Y)
+	if x < 0 || y < 0 || x >= bs.width || y >= bs.height {
+		return 0, 0, false
+	}
+	return y*bs.wordsPerRow + x/64, 1 << (x % 64), true
+}
+
+// grow enlarges the allocation to include a point, leaving headroom so that repeated growth is amortized.  The
+// headroom is clamped to the range of KT.
+func (bs *BitStorage[KT]) grow(p utils.Point[KT]) {
+	minX, minY := int(bs.origin.X), int(bs.origin.Y)
+	maxX, maxY := minX+bs.width-1, minY+bs.height-1
+	if bs.width == 0 || bs.height == 0 {
+		minX, minY, maxX, maxY = int(p.X), int(p.Y), int(p.X), int(p.Y)
+	}
+	padX := max(bs.width/2, 64)
+	padY := max(bs.height/2, 8)
+	if int(p.X) < minX {
+		minX = int(p.X) - padX
+	} else if int(p.X) > maxX {
+		maxX = int(p.X) + padX
+	}
+	if int(p.Y) < minY {
+		minY = int(p.Y) - padY
+	} else if int(p.Y) > maxY {
+		maxY = int(p.Y) + padY
+	}
+	lo, hi := keyRange[KT]()
+	minX, minY = max(minX, lo), max(minY, lo)
+	maxX, maxY = min(maxX, hi), min(maxY, hi)
+	nbs := BitStorage[KT]{}
+	nbs.allocate(maxX-minX+1, maxY-minY+1)
+	nbs.origin = utils.Point[KT]{X: KT(minX), Y: KT(minY)}
+	bs.Iterate(func(q utils.Point[KT], _ bool) bool {
+		nbs.Set(q, true)
+		return true
+	})
+	*bs = nbs
+}
+
+// Set sets the value at a point.  Setting false is the same as Delete.
+func (bs *BitStorage[KT]) Set(p utils.Point[KT], v bool) {
+	if !v {
+		bs.Delete(p)
+		return
+	}
+	w, bit, ok := bs.locate(p)
+	if !ok {
+		bs.grow(p)
+		w, bit, ok = bs.locate(p)
+		if !ok {
+			panic(fmt.Sprintf("BitStorage: %v is outside the allocation after growing", p))
+		}
+	}
+	bs.words[w] |= bit
+}
+
+// Get gets the value at a point.  The bool is returned false if the cell is not set.
+func (bs *BitStorage[KT]) Get(p utils.Point[KT]) (bool, bool) {
+	w, bit, ok := bs.locate(p)
+	if !ok || bs.words[w]&bit == 0 {
+		return false, false
+	}
+	return true, true
+}
+
+// Delete clears the cell at a point
+func (bs *BitStorage[KT]) Delete(p utils.Point[KT]) {
+	w, bit, ok := bs.locate(p)
+	if ok {
+		bs.words[w] &^= bit
+	}
+}
+
+// GetOrDefault gets the value at a point, or a default value if the cell is not set
+func (bs *BitStorage[KT]) GetOrDefault(p utils.Point[KT], def bool) bool {
+	_, ok := bs.Get(p)
+	if ok {
+		return true
+	}
+	return def
+}
+
+// All returns an iterator over each set cell, in row-major order
+func (bs *BitStorage[KT]) All() iter.Seq2[utils.Point[KT], bool] {
+	return func(yield func(utils.Point[KT], bool) bool) {
+		for i, word := range bs.words {
+			y := i / bs.wordsPerRow
+			xBase := (i % bs.wordsPerRow) * 64
+			for word != 0 {
+				x := xBase + bits.TrailingZeros64(word)
+				word &= word - 1
+				if !yield(utils.Point[KT]{X: bs.origin.X + KT(x), Y: bs.origin.Y + KT(y)}, true) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Iterate calls a function for each set cell
+func (bs *BitStorage[KT]) Iterate(iterFunc func(p utils.Point[KT], v bool) bool) {
+	bs.All()(iterFunc)
+}
+
+// IterateOrdered calls a function for each set cell, in row-major order
+func (bs *BitStorage[KT]) IterateOrdered(iterFunc func(p utils.Point[KT], v bool) bool) {
+	bs.All()(iterFunc)
+}
+
+// CopyToBoardStorage returns a copy as a BoardStorage type
+func (bs *BitStorage[KT]) CopyToBoardStorage() BoardStorage[KT, bool] {
+	nbs := *bs
+	nbs.words = append([]uint64(nil), bs.words...)
+	return &nbs
+}
+
+// PopCount returns the number of set cells
+func (bs *BitStorage[KT]) PopCount() int {
+	count := 0
+	for _, w := range bs.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Row returns a view of the row at a given Y coordinate.  Rows outside the allocation are empty.
+func (bs *BitStorage[KT]) Row(y KT) BitRow {
+	ry := int(y) - int(bs.origin.Y)
+	if ry < 0 || ry >= bs.height {
+		return BitRow{}
+	}
+	return BitRow{
+		words:        bs.words[ry*bs.wordsPerRow : (ry+1)*bs.wordsPerRow],
+		width:        bs.width,
+		lastWordMask: bs.lastWordMask,
+	}
+}
+
+// Width returns the number of cells in the row
+func (r BitRow) Width() int {
+	return r.width
+}
+
+// PopCount returns the number of set cells in the row
+func (r BitRow) PopCount() int {
+	count := 0
+	for _, w := range r.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// And sets this row to the bitwise AND of itself and another row of the same width
+func (r BitRow) And(o BitRow) {
+	for i := range r.words {
+		r.words[i] &= o.word(i)
+	}
+}
+
+// Or sets this row to the bitwise OR of itself and another row of the same width
+func (r BitRow) Or(o BitRow) {
+	for i := range r.words {
+		r.words[i] |= o.word(i)
+	}
+}
+
+// Xor sets this row to the bitwise XOR of itself and another row of the same width
+func (r BitRow) Xor(o BitRow) {
+	for i := range r.words {
+		r.words[i] ^= o.word(i)
+	}
+}
+
+// AndNot clears every cell in this row that is set in another row of the same width
+func (r BitRow) AndNot(o BitRow) {
+	for i := range r.words {
+		r.words[i] &^= o.word(i)
+	}
+}
+
+// Shift moves every cell in the row n places towards higher X (or lower X, if n is negative).  Cells shifted
+// off either end are lost.
+func (r BitRow) Shift(n int) {
+	if len(r.words) == 0 || n == 0 {
+		return
+	}
+	wordShift, bitShift := n/64, uint(n%64)
+	if n < 0 {
+		wordShift, bitShift = -n/64, uint(-n%64)
+	}
+	src := append([]uint64(nil), r.words...)
+	get := func(i int) uint64 {
+		if i < 0 || i >= len(src) {
+			return 0
+		}
+		return src[i]
+	}
+	for i := range r.words {
+		if n > 0 {
+			v := get(i-wordShift) << bitShift
+			if bitShift != 0 {
+				v |= get(i-wordShift-1) >> (64 - bitShift)
+			}
+			r.words[i] = v
+		} else {
+			v := get(i+wordShift) >> bitShift
+			if bitShift != 0 {
+				v |= get(i+wordShift+1) << (64 - bitShift)
+			}
+			r.words[i] = v
+		}
+	}
+	r.words[len(r.words)-1] &= r.lastWordMask
+}
+
+// word returns a word of the row, or zero if the row is empty
+func (r BitRow) word(i int) uint64 {
+	if i >= len(r.words) {
+		return 0
+	}
+	return r.words[i]
+}
+
+// Step advances the board by one generation of a cellular automaton with the Moore (8-cell) neighborhood.  The
+// rule is given whether a cell is alive and how many of its neighbors are, and returns whether it is alive in the
+// next generation.  Cells outside the allocation are dead and stay dead.  Rows are computed 64 cells at a time,
+// and large boards are split across goroutines.
+func (bs *BitStorage[KT]) Step(rule func(alive bool, neighbors int) bool) {
+	var born, survives [9]uint64
+	for n := 0; n <= 8; n++ {
+		if rule(false, n) {
+			born[n] = ^uint64(0)
+		}
+		if rule(true, n) {
+			survives[n] = ^uint64(0)
+		}
+	}
+	next := make([]uint64, len(bs.words))
+	workers := runtime.GOMAXPROCS(0)
+	if bs.height*bs.wordsPerRow < 4096 {
+		workers = 1
+	}
+	rowsPer := (bs.height + workers - 1) / max(workers, 1)
+	wg := sync.WaitGroup{}
+	for start := 0; start < bs.height; start += rowsPer {
+		end := min(start+rowsPer, bs.height)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := start; y < end; y++ {
+				bs.stepRow(y, next, &born, &survives)
+			}
+		}()
+	}
+	wg.Wait()
+	bs.words = next
+}
+
+// StepLife advances the board by one generation of Conway's Game of Life
+func (bs *BitStorage[KT]) StepLife() {
+	bs.Step(func(alive bool, neighbors int) bool {
+		return neighbors == 3 || (alive && neighbors == 2)
+	})
+}
+
+// stepRow computes one row of the next generation, using bit-sliced counters to add up the eight neighbors of
+// 64 cells at once
+func (bs *BitStorage[KT]) stepRow(y int, next []uint64, born, survives *[9]uint64) {
+	wpr := bs.wordsPerRow
+	rowWord := func(ry, i int) uint64 {
+		if ry < 0 || ry >= bs.height || i < 0 || i >= wpr {
+			return 0
+		}
+		return bs.words[ry*wpr+i]
+	}
+	for i := 0; i < wpr; i++ {
+		var neighbors [8]uint64
+		n := 0
+		for dy := -1; dy <= 1; dy++ {
+			c := rowWord(y+dy, i)
+			// cells to the west of each bit, and to the east
+			west := c<<1 | rowWord(y+dy, i-1)>>63
+			east := c>>1 | rowWord(y+dy, i+1)<<63
+			neighbors[n] = west
+			neighbors[n+1] = east
+			n += 2
+			if dy != 0 {
+				neighbors[n] = c
+				n++
+			}
+		}
+		var s0, s1, s2, s3 uint64
+		for _, nb := range neighbors {
+			carry := nb
+			s0, carry = s0^carry, s0&carry
+			s1, carry = s1^carry, s1&carry
+			s2, carry = s2^carry, s2&carry
+			s3 |= carry
+		}
+		alive := rowWord(y, i)
+		var result uint64
+		for count := 0; count <= 8; count++ {
+			eq := ^uint64(0)
+			for b, s := range []uint64{s0, s1, s2, s3} {
+				if count&(1<<b) != 0 {
+					eq &= s
+				} else {
+					eq &^= s
+				}
+			}
+			result |= eq & ((alive & survives[count]) | (^alive & born[count]))
+		}
+		if i == wpr-1 {
+			result &= bs.lastWordMask
+		}
+		next[y*wpr+i] = result
+	}
+}
@@ -0,0 +1,206 @@
+package board
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+func TestBitStorage(t *testing.T) {
+	bs := NewBitStorage[int]()
+	points := []utils.Point[int]{{X: 0, Y: 0}, {X: 70, Y: -3}, {X: -65, Y: 2}, {X: 1, Y: 100}}
+	for _, p := range points {
+		bs.Set(p, true)
+	}
+	for _, p := range points {
+		if v, ok := bs.Get(p); !ok || !v {
+			t.Errorf("expected %v to be set", p)
+		}
+	}
+	if _, ok := bs.Get(utils.Point[int]{X: 1, Y: 1}); ok {
+		t.Errorf("expected (1, 1) not to be set")
+	}
+	if bs.PopCount() != len(points) {
+		t.Errorf("expected %d cells, got %d", len(points), bs.PopCount())
+	}
+	var order []utils.Point[int]
+	bs.IterateOrdered(func(p utils.Point[int], _ bool) bool {
+		order = append(order, p)
+		return true
+	})
+	want := []utils.Point[int]{{X: 70, Y: -3}, {X: 0, Y: 0}, {X: -65, Y: 2}, {X: 1, Y: 100}}
+	if !slices.Equal(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+	bs.Set(utils.Point[int]{X: 70, Y: -3}, false)
+	bs.Delete(utils.Point[int]{X: 0, Y: 0})
+	if bs.PopCount() != 2 || bs.GetOrDefault(utils.Point[int]{X: 0, Y: 0}, false) {
+		t.Errorf("expected setting false and Delete to clear cells")
+	}
+	c := bs.CopyToBoardStorage()
+	c.Set(utils.Point[int]{X: 5, Y: 5}, true)
+	if _, ok := bs.Get(utils.Point[int]{X: 5, Y: 5}); ok {
+		t.Errorf("expected a copy not to share storage")
+	}
+}
+
+func TestBitStorageSmallKeyType(t *testing.T) {
+	bs := NewBitStorage[int8]()
+	bs.Set(utils.Point[int8]{X: 100, Y: 100}, true)
+	bs.Set(utils.Point[int8]{X: -100, Y: -100}, true)
+	bs.Set(utils.Point[int8]{X: 127, Y: -128}, true)
+	for _, p := range []utils.Point[int8]{{X: 100, Y: 100}, {X: -100, Y: -100}, {X: 127, Y: -128}} {
+		if _, ok := bs.Get(p); !ok {
+			t.Errorf("expected %v to be set", p)
+		}
+	}
+	if bs.PopCount() != 3 {
+		t.Errorf("expected 3 cells, got %d", bs.PopCount())
+	}
+	ubs := NewBitStorage[uint8]()
+	ubs.Set(utils.Point[uint8]{X: 200, Y: 200}, true)
+	ubs.Set(utils.Point[uint8]{X: 0, Y: 0}, true)
+	if ubs.PopCount() != 2 || !ubs.GetOrDefault(utils.Point[uint8]{X: 0, Y: 0}, false) {
+		t.Errorf("expected both corners of a uint8 board to be set")
+	}
+}
+
+// rowBits returns the X offsets of the set cells of a row
+func rowBits(r BitRow) []int {
+	var xs []int
+	for i, w := range r.words {
+		for b := range 64 {
+			if w&(1<<b) != 0 {
+				xs = append(xs, i*64+b)
+			}
+		}
+	}
+	return xs
+}
+
+func TestBitRowShift(t *testing.T) {
+	const width = 150
+	cells := []int{0, 1, 63, 64, 65, 100, 127, 128, 149}
+	for _, n := range []int{0, 1, -1, 5, -5, 63, -63, 64, -64, 65, -65, 130, -130, 200, -200} {
+		bs := NewBitStorage[int]()
+		bs.Allocate(width, 1, false)
+		for _, x := range cells {
+			bs.Set(utils.Point[int]{X: x, Y: 0}, true)
+		}
+		bs.Row(0).Shift(n)
+		var want []int
+		for _, x := range cells {
+			if x+n >= 0 && x+n < width {
+				want = append(want, x+n)
+			}
+		}
+		if got := rowBits(bs.Row(0)); !slices.Equal(got, want) {
+			t.Errorf("Shift(%d): expected %v, got %v", n, want, got)
+		}
+	}
+	if r := NewBitStorage[int]().Row(3); r.Width() != 0 || r.PopCount() != 0 {
+		t.Errorf("expected a row outside the allocation to be empty")
+	}
+}
+
+func TestBitRowOps(t *testing.T) {
+	bs := NewBitStorage[int]()
+	bs.Allocate(100, 2, false)
+	for _, x := range []int{1, 2, 70} {
+		bs.Set(utils.Point[int]{X: x, Y: 0}, true)
+	}
+	for _, x := range []int{2, 3, 70, 99} {
+		bs.Set(utils.Point[int]{X: x, Y: 1}, true)
+	}
+	tests := []struct {
+		name string
+		op   func(r, o BitRow)
+		want []int
+	}{
+		{"And", BitRow.And, []int{2, 70}},
+		{"Or", BitRow.Or, []int{1, 2, 3, 70, 99}},
+		{"Xor", BitRow.Xor, []int{1, 3, 99}},
+		{"AndNot", BitRow.AndNot, []int{1}},
+	}
+	for _, tt := range tests {
+		c := bs.CopyToBoardStorage().(*BitStorage[int])
+		tt.op(c.Row(0), c.Row(1))
+		if got := rowBits(c.Row(0)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// naiveLife computes the next generation of Life within the allocation of a BitStorage, one cell at a time
+func naiveLife(bs *BitStorage[int]) map[utils.Point[int]]bool {
+	next := make(map[utils.Point[int]]bool)
+	for y := bs.origin.Y; y < bs.origin.Y+bs.height; y++ {
+		for x := bs.origin.X; x < bs.origin.X+bs.width; x++ {
+			n := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if dx == 0 && dy == 0 {
+						continue
+					}
+					if _, ok := bs.Get(utils.Point[int]{X: x + dx, Y: y + dy}); ok {
+						n++
+					}
+				}
+			}
+			_, alive := bs.Get(utils.Point[int]{X: x, Y: y})
+			if n == 3 || (alive && n == 2) {
+				next[utils.Point[int]{X: x, Y: y}] = true
+			}
+		}
+	}
+	return next
+}
+
+func TestBitStorageStep(t *testing.T) {
+	// tall enough that Step splits the rows across goroutines
+	const width, height = 150, 1400
+	rng := rand.New(rand.NewSource(1))
+	bs := NewBitStorage[int]()
+	bs.Allocate(width, height, false)
+	for y := range height {
+		for x := range width {
+			if rng.Intn(3) == 0 {
+				bs.Set(utils.Point[int]{X: x, Y: y}, true)
+			}
+		}
+	}
+	for gen := range 3 {
+		want := naiveLife(bs)
+		bs.StepLife()
+		got := make(map[utils.Point[int]]bool)
+		bs.Iterate(func(p utils.Point[int], _ bool) bool {
+			got[p] = true
+			return true
+		})
+		if len(got) != len(want) {
+			t.Fatalf("generation %d: expected %d cells, got %d", gen+1, len(want), len(got))
+		}
+		for p := range want {
+			if !got[p] {
+				t.Fatalf("generation %d: expected %v to be alive", gen+1, p)
+			}
+		}
+	}
+	blinker := NewBitStorage[int]()
+	blinker.Allocate(130, 10, false)
+	for x := 63; x <= 65; x++ {
+		blinker.Set(utils.Point[int]{X: x, Y: 5}, true)
+	}
+	blinker.StepLife()
+	var cells []utils.Point[int]
+	blinker.IterateOrdered(func(p utils.Point[int], _ bool) bool {
+		cells = append(cells, p)
+		return true
+	})
+	want := []utils.Point[int]{{X: 64, Y: 4}, {X: 64, Y: 5}, {X: 64, Y: 6}}
+	if !slices.Equal(cells, want) {
+		t.Errorf("expected a blinker across a word boundary to turn vertical, got %v", cells)
+	}
+}
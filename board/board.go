@@ -3,6 +3,7 @@ package board
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/ghjm/advent_utils"
@@ -21,6 +22,8 @@ type BoardOptions[KT constraints.Integer, VT any] struct {
 	emptyVal VT
 	convFunc func(uint8) VT
 	compFunc func(VT, VT) bool
+	wrap     bool
+	tiling   bool
 }
 
 // WithStorage provides a storage backend to a Board
@@ -58,6 +61,23 @@ func WithCompareFunc[KT constraints.Integer, VT any](compFunc func(VT, VT) bool)
 	}
 }
 
+// WithWrap makes the board toroidal: coordinates are taken modulo the bounds, so anything that moves off one edge
+// comes back on the opposite edge.  Get, Set, Clear, the neighbor functions and Search all normalize their points.
+func WithWrap[KT constraints.Integer, VT any]() func(*BoardOptions[KT, VT]) {
+	return func(options *BoardOptions[KT, VT]) {
+		options.wrap = true
+	}
+}
+
+// WithTiling makes the board an infinite plane tiled with copies of the area within the bounds.  Get, Set and
+// Clear map points back into the base tile, but unlike WithWrap, neighbor functions and Search keep their
+// un-normalized coordinates, so distances across tiles can be measured.
+func WithTiling[KT constraints.Integer, VT any]() func(*BoardOptions[KT, VT]) {
+	return func(options *BoardOptions[KT, VT]) {
+		options.tiling = true
+	}
+}
+
 // NewBoard allocates and initializes a new Board
 func NewBoard[KT constraints.Integer, VT any](options ...func(board *BoardOptions[KT, VT])) *Board[KT, VT] {
 	b := Board[KT, VT]{}
@@ -246,6 +266,33 @@ func (b *Board[KT, VT]) MustFromFile(name string) {
 	}
 }
 
+// StdBoardWithSections reads a file made of blank-line-separated sections, where the first section is a board
+// and the remaining sections are returned as lines
+func StdBoardWithSections(name string) (*StdBoard, [][]string, error) {
+	sections, err := utils.OpenAndReadSections(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sections) == 0 {
+		return nil, nil, fmt.Errorf("no sections found in %s", name)
+	}
+	b := NewStdBoard()
+	err = b.FromStrings(sections[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, sections[1:], nil
+}
+
+// MustStdBoardWithSections reads a board followed by sections of lines, and panics on any error
+func MustStdBoardWithSections(name string) (*StdBoard, [][]string) {
+	b, rest, err := StdBoardWithSections(name)
+	if err != nil {
+		panic(err)
+	}
+	return b, rest
+}
+
 // orderBounds ensures the boundary is in the correct order
 func (b *Board[KT, VT]) orderBounds() {
 	if b.bounds == nil {
@@ -299,41 +346,69 @@ func (b *Board[KT, VT]) ExpandBounds(p utils.Point[KT]) {
 	}
 }
 
-// Contains returns true if the given point is contained within the board's boundary rectangle
+// Contains returns true if the given point is contained within the board's boundary rectangle.  On a wrapping or
+// tiled board, every point is contained.
 func (b *Board[KT, VT]) Contains(p utils.Point[KT]) bool {
-	if b.bounds == nil {
+	if b.bounds == nil || b.wrap || b.tiling {
 		return true
 	}
 	return p.Within(*b.bounds)
 }
 
+// Wraps returns true if the board wraps or tiles at its bounds
+func (b *Board[KT, VT]) Wraps() bool {
+	return (b.wrap || b.tiling) && b.bounds != nil
+}
+
+// Normalize maps a point into the boundary rectangle, modulo its width and height.  Points are returned unchanged
+// if the board does not wrap or tile, or has no bounds.
+func (b *Board[KT, VT]) Normalize(p utils.Point[KT]) utils.Point[KT] {
+	if !b.Wraps() {
+		return p
+	}
+	b.orderBounds()
+	return utils.Point[KT]{
+		X: b.bounds.P1.X + KT(utils.Mod(int(p.X)-int(b.bounds.P1.X), int(b.bounds.Width()))),
+		Y: b.bounds.P1.Y + KT(utils.Mod(int(p.Y)-int(b.bounds.P1.Y), int(b.bounds.Height()))),
+	}
+}
+
+// neighbor normalizes a point returned by a neighbor function, which only happens in wrap mode
+func (b *Board[KT, VT]) neighbor(p utils.Point[KT]) utils.Point[KT] {
+	if b.wrap {
+		return b.Normalize(p)
+	}
+	return p
+}
+
 // Get returns the value of a location on the board
 func (b *Board[KT, VT]) Get(p utils.Point[KT]) VT {
-	return b.storage.GetOrDefault(p, b.emptyVal)
+	return b.storage.GetOrDefault(b.Normalize(p), b.emptyVal)
 }
 
 // GetRune returns only the rune from a location on the board
 func (b *RunePlusBoard[KT, ET]) GetRune(p utils.Point[KT]) rune {
-	return b.storage.GetOrDefault(p, b.emptyVal).Value
+	return b.storage.GetOrDefault(b.Normalize(p), b.emptyVal).Value
 }
 
 // GetExtra returns only the extra value from a location on the board
 func (b *RunePlusBoard[KT, ET]) GetExtra(p utils.Point[KT]) ET {
-	return b.storage.GetOrDefault(p, b.emptyVal).Extra
+	return b.storage.GetOrDefault(b.Normalize(p), b.emptyVal).Extra
 }
 
 // Set sets the value of a location on the board
 func (b *Board[KT, VT]) Set(p utils.Point[KT], v VT) {
-	b.storage.Set(p, v)
+	b.storage.Set(b.Normalize(p), v)
 }
 
 // SetRuneOnly sets the rune and clears any extra data
 func (b *RunePlusBoard[KT, ET]) SetRuneOnly(p utils.Point[KT], v rune) {
-	b.storage.Set(p, RunePlusData[ET]{Value: v})
+	b.storage.Set(b.Normalize(p), RunePlusData[ET]{Value: v})
 }
 
 // SetRune sets the rune, preserving extra data if present
 func (b *RunePlusBoard[KT, ET]) SetRune(p utils.Point[KT], v rune) {
+	p = b.Normalize(p)
 	c, ok := b.storage.Get(p)
 	var ev ET
 	if ok {
@@ -344,51 +419,95 @@ func (b *RunePlusBoard[KT, ET]) SetRune(p utils.Point[KT], v rune) {
 
 // SetExtra sets the extra data, preserving the rune value.  If the rune had no value, the empty value is added.
 func (b *RunePlusBoard[KT, ET]) SetExtra(p utils.Point[KT], v ET) {
+	p = b.Normalize(p)
 	c := b.storage.GetOrDefault(p, b.emptyVal)
 	b.storage.Set(p, RunePlusData[ET]{Value: c.Value, Extra: v})
 }
 
 // Clear clears the value of a location on the board
 func (b *Board[KT, VT]) Clear(p utils.Point[KT]) {
-	b.storage.Delete(p)
+	b.storage.Delete(b.Normalize(p))
 }
 
-// SetAndExpandBounds sets a point and also ensures that this point is within the boundary rectangle
+// SetAndExpandBounds sets a point and also ensures that this point is within the boundary rectangle.  On a
+// wrapping or tiled board the point is normalized first, so it lands in the base tile and the bounds are unchanged.
 func (b *Board[KT, VT]) SetAndExpandBounds(p utils.Point[KT], v VT) {
+	p = b.Normalize(p)
 	b.storage.Set(p, v)
 	b.ExpandBounds(p)
 }
 
+// All returns an iterator over every populated location on the board.  No guarantees are made about ordering.
+func (b *Board[KT, VT]) All() iter.Seq2[utils.Point[KT], VT] {
+	return func(yield func(utils.Point[KT], VT) bool) {
+		b.storage.Iterate(yield)
+	}
+}
+
+// AllOrdered returns an iterator over every populated location on the board, in deterministic order
+func (b *Board[KT, VT]) AllOrdered() iter.Seq2[utils.Point[KT], VT] {
+	return func(yield func(utils.Point[KT], VT) bool) {
+		b.storage.IterateOrdered(yield)
+	}
+}
+
+// Points returns an iterator over every populated point on the board.  No guarantees are made about ordering.
+func (b *Board[KT, VT]) Points() iter.Seq[utils.Point[KT]] {
+	return func(yield func(utils.Point[KT]) bool) {
+		for p := range b.All() {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// InBounds returns an iterator over every point within the boundary rectangle, whether or not it is populated
+func (b *Board[KT, VT]) InBounds() iter.Seq[utils.Point[KT]] {
+	return func(yield func(utils.Point[KT]) bool) {
+		if b.bounds == nil {
+			return
+		}
+		b.orderBounds()
+		for y := b.bounds.P1.Y; y <= b.bounds.P2.Y; y++ {
+			for x := b.bounds.P1.X; x <= b.bounds.P2.X; x++ {
+				if !yield(utils.Point[KT]{X: x, Y: y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Runes returns an iterator over every populated location on the board, returning only the rune
+func (b *RunePlusBoard[KT, VT]) Runes() iter.Seq2[utils.Point[KT], rune] {
+	return func(yield func(utils.Point[KT], rune) bool) {
+		for p, v := range b.All() {
+			if !yield(p, v.Value) {
+				return
+			}
+		}
+	}
+}
+
 // Iterate calls a function for every populated location on the board.  No guarantees are made about ordering.
 func (b *Board[KT, VT]) Iterate(iterFunc func(p utils.Point[KT], v VT) bool) {
-	b.storage.Iterate(iterFunc)
+	b.All()(iterFunc)
 }
 
 // IterateOrdered calls a function for every populated location on the board, in deterministic order
 func (b *Board[KT, VT]) IterateOrdered(iterFunc func(p utils.Point[KT], v VT) bool) {
-	b.storage.IterateOrdered(iterFunc)
+	b.AllOrdered()(iterFunc)
 }
 
 // IterateRunes calls a function for every populated location on the board, returning only the rune
 func (b *RunePlusBoard[KT, VT]) IterateRunes(iterFunc func(p utils.Point[KT], v rune) bool) {
-	b.storage.Iterate(func(p utils.Point[KT], v RunePlusData[VT]) bool {
-		return iterFunc(p, v.Value)
-	})
+	b.Runes()(iterFunc)
 }
 
 // IterateBounds calls a function for every point within the boundary rectangle, whether or not it is populated
 func (b *Board[KT, VT]) IterateBounds(pFunc func(utils.Point[KT]) bool) {
-	if b.bounds == nil {
-		return
-	}
-	b.orderBounds()
-	for y := b.bounds.P1.Y; y <= b.bounds.P2.Y; y++ {
-		for x := b.bounds.P1.X; x <= b.bounds.P2.X; x++ {
-			if !pFunc(utils.Point[KT]{x, y}) {
-				return
-			}
-		}
-	}
+	b.InBounds()(pFunc)
 }
 
 // Copy returns a new copy of the board
@@ -398,6 +517,8 @@ func (b *Board[KT, VT]) Copy() *Board[KT, VT] {
 	nb.emptyVal = b.emptyVal
 	nb.compFunc = b.compFunc
 	nb.convFunc = b.convFunc
+	nb.wrap = b.wrap
+	nb.tiling = b.tiling
 	if b.bounds != nil {
 		nb.bounds = &utils.Rectangle[KT]{
 			P1: utils.Point[KT]{
@@ -456,14 +577,15 @@ func (b *Board[KT, VT]) Print(fFunc func(VT) rune) {
 	}
 }
 
-// Cardinals returns the four cardinal points adjacent to a given point.
+// Cardinals returns the four cardinal points adjacent to a given point.  On a wrapping board, the points are
+// normalized.
 func (b *Board[KT, VT]) Cardinals(p utils.Point[KT], includeOffBoard bool) []utils.Point[KT] {
 	var results []utils.Point[KT]
 	for _, d := range []utils.StdPoint{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
-		np := utils.Point[KT]{
+		np := b.neighbor(utils.Point[KT]{
 			X: p.X + KT(d.X),
 			Y: p.Y + KT(d.Y),
-		}
+		})
 		if includeOffBoard || b.Contains(np) {
 			results = append(results, np)
 		}
@@ -471,14 +593,15 @@ func (b *Board[KT, VT]) Cardinals(p utils.Point[KT], includeOffBoard bool) []uti
 	return results
 }
 
-// Diagonals returns the eight diagonal (including cardinal) points adjacent to a given point.
+// Diagonals returns the eight diagonal (including cardinal) points adjacent to a given point.  On a wrapping
+// board, the points are normalized.
 func (b *Board[KT, VT]) Diagonals(p utils.Point[KT], includeOffBoard bool) []utils.Point[KT] {
 	var results []utils.Point[KT]
 	for _, d := range []utils.StdPoint{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}} {
-		np := utils.Point[KT]{
+		np := b.neighbor(utils.Point[KT]{
 			X: p.X + KT(d.X),
 			Y: p.Y + KT(d.Y),
-		}
+		})
 		if includeOffBoard || b.Contains(np) {
 			results = append(results, np)
 		}
@@ -539,17 +662,18 @@ func (b *Board[KT, VT]) Compare(b2 *Board[KT, VT]) bool {
 }
 
 // Search performs a flood fill type search of a board from a given start point and with a given neighbors function.
+// On a wrapping board, the start point and neighbors are normalized.
 func (b *Board[KT, VT]) Search(start utils.Point[KT], neighbors func(p utils.Point[KT]) []utils.Point[KT]) map[utils.Point[KT]]struct{} {
-	open := []utils.Point[KT]{start}
+	open := []utils.Point[KT]{b.neighbor(start)}
 	visited := make(map[utils.Point[KT]]struct{})
-	for len(open) > 0 {
-		cur := open[0]
-		open = open[1:]
+	for head := 0; head < len(open); head++ {
+		cur := open[head]
 		if _, ok := visited[cur]; ok {
 			continue
 		}
 		visited[cur] = struct{}{}
 		for _, p := range neighbors(cur) {
+			p = b.neighbor(p)
 			if _, ok := visited[p]; !ok {
 				open = append(open, p)
 			}
@@ -1,6 +1,8 @@
 package board
 
 import (
+	"iter"
+
 	utils "github.com/ghjm/advent_utils"
 	"golang.org/x/exp/constraints"
 )
@@ -66,16 +68,22 @@ func (fb *FlatBoard) GetOrDefault(p utils.StdPoint, def rune) rune {
 	return def
 }
 
-func (fb *FlatBoard) Iterate(iterFunc func(p utils.StdPoint, v rune) bool) {
-	for y := 0; y < len(fb.board); y++ {
-		for x := 0; x < len(fb.board[0]); x++ {
-			if !iterFunc(utils.StdPoint{x, y}, fb.board[y][x]) {
-				return
+func (fb *FlatBoard) All() iter.Seq2[utils.StdPoint, rune] {
+	return func(yield func(utils.StdPoint, rune) bool) {
+		for y := 0; y < len(fb.board); y++ {
+			for x := 0; x < len(fb.board[0]); x++ {
+				if !yield(utils.StdPoint{X: x, Y: y}, fb.board[y][x]) {
+					return
+				}
 			}
 		}
 	}
 }
 
+func (fb *FlatBoard) Iterate(iterFunc func(p utils.StdPoint, v rune) bool) {
+	fb.All()(iterFunc)
+}
+
 func (fb *FlatBoard) IterateOrdered(iterFunc func(p utils.StdPoint, v rune) bool) {
 	fb.Iterate(iterFunc)
 }
@@ -0,0 +1,139 @@
+package board
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// sortedPoints returns the points of a set in row-major order
+func sortedPoints(m map[utils.Point[int]]struct{}) []utils.Point[int] {
+	var ps []utils.Point[int]
+	for p := range m {
+		ps = append(ps, p)
+	}
+	slices.SortFunc(ps, func(a, b utils.Point[int]) int {
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+		return a.X - b.X
+	})
+	return ps
+}
+
+func TestWrap(t *testing.T) {
+	b := NewRuneBoard[int](WithWrap[int, rune]())
+	b.MustFromStrings([]string{
+		"#..",
+		"...",
+		"..@",
+	})
+	tests := []struct {
+		p    utils.Point[int]
+		want rune
+	}{
+		{utils.Point[int]{X: 3, Y: 0}, '#'},
+		{utils.Point[int]{X: -3, Y: -3}, '#'},
+		{utils.Point[int]{X: -1, Y: -1}, '@'},
+		{utils.Point[int]{X: 302, Y: -298}, '@'},
+		{utils.Point[int]{X: 4, Y: 4}, '.'},
+	}
+	for _, tt := range tests {
+		if got := b.Get(tt.p); got != tt.want {
+			t.Errorf("Get(%v): expected %c, got %c", tt.p, tt.want, got)
+		}
+	}
+	b.Set(utils.Point[int]{X: -2, Y: 4}, 'x')
+	if b.Get(utils.Point[int]{X: 1, Y: 1}) != 'x' {
+		t.Errorf("expected Set to normalize its point")
+	}
+	b.SetAndExpandBounds(utils.Point[int]{X: 5, Y: -1}, 'y')
+	if b.Get(utils.Point[int]{X: 2, Y: 2}) != 'y' {
+		t.Errorf("expected SetAndExpandBounds to normalize its point")
+	}
+	if b.Bounds() != (utils.Rectangle[int]{P2: utils.Point[int]{X: 2, Y: 2}}) {
+		t.Errorf("expected SetAndExpandBounds not to change the bounds of a wrapping board, got %v", b.Bounds())
+	}
+	b.Clear(utils.Point[int]{X: 4, Y: 4})
+	if b.Get(utils.Point[int]{X: 1, Y: 1}) != '.' {
+		t.Errorf("expected Clear to normalize its point")
+	}
+	for p := range b.All() {
+		if !p.Within(b.Bounds()) {
+			t.Errorf("expected every stored point to be within the bounds, got %v", p)
+		}
+	}
+
+	got := b.Cardinals(utils.Point[int]{X: 0, Y: 0}, false)
+	want := []utils.Point[int]{{X: 2, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Cardinals: expected %v, got %v", want, got)
+	}
+	if d := b.Diagonals(utils.Point[int]{X: 2, Y: 2}, false); len(d) != 8 || d[0] != (utils.Point[int]{X: 1, Y: 1}) ||
+		d[7] != (utils.Point[int]{X: 0, Y: 0}) {
+		t.Errorf("Diagonals: unexpected %v", d)
+	}
+
+	line := NewRuneBoard[int](WithWrap[int, rune]())
+	line.MustFromStrings([]string{"....."})
+	reached := line.Search(utils.Point[int]{X: 7, Y: 0}, func(p utils.Point[int]) []utils.Point[int] {
+		return []utils.Point[int]{{X: p.X + 2, Y: p.Y}}
+	})
+	if len(reached) != 5 {
+		t.Errorf("expected a search stepping by 2 to reach every cell of a wrapping line, got %v", sortedPoints(reached))
+	}
+}
+
+func TestTiling(t *testing.T) {
+	b := NewRuneBoard[int](WithTiling[int, rune]())
+	b.MustFromStrings([]string{
+		".#.",
+		"...",
+		"...",
+	})
+	if b.Get(utils.Point[int]{X: 4, Y: -3}) != '#' || b.Get(utils.Point[int]{X: -2, Y: 6}) != '#' {
+		t.Errorf("expected Get to map points into the base tile")
+	}
+	b.Set(utils.Point[int]{X: -1, Y: -1}, 'x')
+	if b.Get(utils.Point[int]{X: 2, Y: 2}) != 'x' {
+		t.Errorf("expected Set to map points into the base tile")
+	}
+	b.SetAndExpandBounds(utils.Point[int]{X: 3, Y: 4}, 'y')
+	if b.Get(utils.Point[int]{X: 0, Y: 1}) != 'y' || b.Bounds().P2 != (utils.Point[int]{X: 2, Y: 2}) {
+		t.Errorf("expected SetAndExpandBounds to map into the base tile, got bounds %v", b.Bounds())
+	}
+	b.Clear(utils.Point[int]{X: 3, Y: 4})
+	if b.Get(utils.Point[int]{X: 0, Y: 1}) != '.' {
+		t.Errorf("expected Clear to map points into the base tile")
+	}
+
+	got := b.Cardinals(utils.Point[int]{X: 0, Y: 0}, false)
+	want := []utils.Point[int]{{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Cardinals: expected un-normalized points %v, got %v", want, got)
+	}
+
+	// on a tiled plane, the cells within 2 steps that avoid walls include copies in neighboring tiles
+	start := utils.Point[int]{X: 1, Y: 1}
+	dist := map[utils.Point[int]]int{start: 0}
+	reached := b.Search(start, func(p utils.Point[int]) []utils.Point[int] {
+		var next []utils.Point[int]
+		for _, n := range b.Cardinals(p, false) {
+			if _, ok := dist[n]; !ok && dist[p] < 2 && b.Get(n) != '#' {
+				dist[n] = dist[p] + 1
+				next = append(next, n)
+			}
+		}
+		return next
+	})
+	if _, ok := reached[utils.Point[int]{X: 3, Y: 1}]; !ok {
+		t.Errorf("expected the search to reach (3, 1) outside the base tile, got %v", sortedPoints(reached))
+	}
+	if _, ok := reached[utils.Point[int]{X: 1, Y: 3}]; ok {
+		t.Errorf("expected the search not to enter a copy of a wall")
+	}
+	if len(reached) != 10 {
+		t.Errorf("expected 10 cells within 2 steps, got %d: %v", len(reached), sortedPoints(reached))
+	}
+}
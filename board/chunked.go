@@ -0,0 +1,237 @@
+package board
+
+import (
+	"iter"
+	"math/bits"
+	"reflect"
+	"sort"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// chunkSize is the width and height of each tile in a ChunkedStorage
+const chunkSize = 32
+
+const chunkCells = chunkSize * chunkSize
+
+// AllOrdered reads each cell row of a chunk as half of one presence word, which only works for this chunk size
+var _ = [1]struct{}{}[2*chunkSize-64]
+
+// chunk is a dense square tile of a ChunkedStorage
+type chunk[VT any] struct {
+	data    [chunkCells]VT
+	present [chunkCells / 64]uint64
+	count   int
+}
+
+// ChunkedStorage is a sparse BoardStorage for very large or unbounded boards.  It keeps fixed-size dense tiles in
+// a map keyed by chunk coordinate, and drops tiles that become entirely empty.  Once Allocate has supplied an empty
+// value, setting a point to it is the same as Delete, so that clearing cells this way also frees their tiles.
+type ChunkedStorage[KT constraints.Integer, VT any] struct {
+	chunks   map[utils.Point[KT]]*chunk[VT]
+	emptyVal VT
+	isEmpty  func(VT) bool
+	count    int
+}
+
+// NewChunkedStorage allocates and initializes a new ChunkedStorage
+func NewChunkedStorage[KT constraints.Integer, VT any]() *ChunkedStorage[KT, VT] {
+	return &ChunkedStorage[KT, VT]{
+		chunks: make(map[utils.Point[KT]]*chunk[VT]),
+	}
+}
+
+// WithChunkedStorage provides a new ChunkedStorage backend to a Board.  Each board the option is applied to gets
+// its own storage.
+func WithChunkedStorage[KT constraints.Integer, VT any]() func(*BoardOptions[KT, VT]) {
+	return func(options *BoardOptions[KT, VT]) {
+		options.storage = NewChunkedStorage[KT, VT]()
+	}
+}
+
+// floorDiv divides, rounding towards negative infinity
+func floorDiv[KT constraints.Integer](a, b KT) KT {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// locate returns the chunk coordinate of a point and the point's index within the chunk
+func (cs *ChunkedStorage[KT, VT]) locate(p utils.Point[KT]) (utils.Point[KT], int) {
+	key := utils.Point[KT]{X: floorDiv(p.X, chunkSize), Y: floorDiv(p.Y, chunkSize)}
+	lx := int(p.X - key.X*chunkSize)
+	ly := int(p.Y - key.Y*chunkSize)
+	return key, ly*chunkSize + lx
+}
+
+// Allocate discards all data.  Chunks are allocated as needed, so the size is ignored.
+func (cs *ChunkedStorage[KT, VT]) Allocate(_, _ KT, emptyVal VT) {
+	cs.chunks = make(map[utils.Point[KT]]*chunk[VT])
+	cs.emptyVal = emptyVal
+	cs.isEmpty = nil
+	// values of types that can't be compared, or that are interfaces and might panic when compared, are never
+	// treated as empty
+	if t := reflect.TypeFor[VT](); t.Comparable() && t.Kind() != reflect.Interface {
+		cs.isEmpty = func(v VT) bool {
+			return any(v) == any(emptyVal)
+		}
+	}
+	cs.count = 0
+}
+
+// Set sets the value at a point.  Setting the empty value given to Allocate is the same as Delete.
+func (cs *ChunkedStorage[KT, VT]) Set(p utils.Point[KT], v VT) {
+	if cs.isEmpty != nil && cs.isEmpty(v) {
+		cs.Delete(p)
+		return
+	}
+	if cs.chunks == nil {
+		cs.chunks = make(map[utils.Point[KT]]*chunk[VT])
+	}
+	key, i := cs.locate(p)
+	c, ok := cs.chunks[key]
+	if !ok {
+		c = &chunk[VT]{}
+		cs.chunks[key] = c
+	}
+	c.data[i] = v
+	if c.present[i/64]&(1<<(i%64)) == 0 {
+		c.present[i/64] |= 1 << (i % 64)
+		c.count++
+		cs.count++
+	}
+}
+
+// Get gets the value at a point
+func (cs *ChunkedStorage[KT, VT]) Get(p utils.Point[KT]) (VT, bool) {
+	key, i := cs.locate(p)
+	c, ok := cs.chunks[key]
+	if !ok || c.present[i/64]&(1<<(i%64)) == 0 {
+		var zv VT
+		return zv, false
+	}
+	return c.data[i], true
+}
+
+// Delete removes the value at a point, dropping its chunk if the chunk becomes empty
+func (cs *ChunkedStorage[KT, VT]) Delete(p utils.Point[KT]) {
+	key, i := cs.locate(p)
+	c, ok := cs.chunks[key]
+	if !ok || c.present[i/64]&(1<<(i%64)) == 0 {
+		return
+	}
+	c.present[i/64] &^= 1 << (i % 64)
+	c.data[i] = cs.emptyVal
+	c.count--
+	cs.count--
+	if c.count == 0 {
+		delete(cs.chunks, key)
+	}
+}
+
+// GetOrDefault gets the value at a point, or a default value if no value is present
+func (cs *ChunkedStorage[KT, VT]) GetOrDefault(p utils.Point[KT], def VT) VT {
+	v, ok := cs.Get(p)
+	if ok {
+		return v
+	}
+	return def
+}
+
+// Len returns the number of points with values
+func (cs *ChunkedStorage[KT, VT]) Len() int {
+	return cs.count
+}
+
+// ChunkCount returns the number of allocated chunks
+func (cs *ChunkedStorage[KT, VT]) ChunkCount() int {
+	return len(cs.chunks)
+}
+
+// All returns an iterator over each point with a value.  No guarantees are made about ordering.
+func (cs *ChunkedStorage[KT, VT]) All() iter.Seq2[utils.Point[KT], VT] {
+	return func(yield func(utils.Point[KT], VT) bool) {
+		for key, c := range cs.chunks {
+			for w, word := range c.present {
+				for word != 0 {
+					i := w*64 + bits.TrailingZeros64(word)
+					word &= word - 1
+					p := utils.Point[KT]{
+						X: key.X*chunkSize + KT(i%chunkSize),
+						Y: key.Y*chunkSize + KT(i/chunkSize),
+					}
+					if !yield(p, c.data[i]) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// AllOrdered returns an iterator over each point with a value, in row-major order.  Chunks are walked in
+// row-major order, and each row of chunks is walked one cell row at a time.
+func (cs *ChunkedStorage[KT, VT]) AllOrdered() iter.Seq2[utils.Point[KT], VT] {
+	return func(yield func(utils.Point[KT], VT) bool) {
+		keys := make([]utils.Point[KT], 0, len(cs.chunks))
+		for key := range cs.chunks {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].Y < keys[j].Y || (keys[i].Y == keys[j].Y && keys[i].X < keys[j].X)
+		})
+		for start := 0; start < len(keys); {
+			end := start
+			for end < len(keys) && keys[end].Y == keys[start].Y {
+				end++
+			}
+			for ly := 0; ly < chunkSize; ly++ {
+				for _, key := range keys[start:end] {
+					c := cs.chunks[key]
+					// each cell row of a chunk is half of one presence word
+					word := (c.present[ly/2] >> ((ly % 2) * chunkSize)) & (1<<chunkSize - 1)
+					for word != 0 {
+						lx := bits.TrailingZeros64(word)
+						word &= word - 1
+						p := utils.Point[KT]{
+							X: key.X*chunkSize + KT(lx),
+							Y: key.Y*chunkSize + KT(ly),
+						}
+						if !yield(p, c.data[ly*chunkSize+lx]) {
+							return
+						}
+					}
+				}
+			}
+			start = end
+		}
+	}
+}
+
+// Iterate calls a function for each point with a value.  No guarantees are made about ordering.
+func (cs *ChunkedStorage[KT, VT]) Iterate(iterFunc func(p utils.Point[KT], v VT) bool) {
+	cs.All()(iterFunc)
+}
+
+// IterateOrdered calls a function for each point with a value, in row-major order
+func (cs *ChunkedStorage[KT, VT]) IterateOrdered(iterFunc func(p utils.Point[KT], v VT) bool) {
+	cs.AllOrdered()(iterFunc)
+}
+
+// CopyToBoardStorage returns a copy as a BoardStorage type
+func (cs *ChunkedStorage[KT, VT]) CopyToBoardStorage() BoardStorage[KT, VT] {
+	ncs := &ChunkedStorage[KT, VT]{
+		chunks:   make(map[utils.Point[KT]]*chunk[VT], len(cs.chunks)),
+		emptyVal: cs.emptyVal,
+		isEmpty:  cs.isEmpty,
+		count:    cs.count,
+	}
+	for key, c := range cs.chunks {
+		nc := *c
+		ncs.chunks[key] = &nc
+	}
+	return ncs
+}
@@ -0,0 +1,98 @@
+package board
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+func TestChunkedStorageOrdered(t *testing.T) {
+	cs := NewChunkedStorage[int, rune]()
+	points := []utils.Point[int]{
+		{X: 40, Y: -1},
+		{X: -33, Y: -1},
+		{X: -1, Y: -1},
+		{X: 0, Y: 0},
+		{X: -64, Y: 31},
+		{X: 31, Y: 31},
+		{X: -1, Y: -32},
+		{X: 5, Y: 32},
+		{X: -100, Y: -33},
+	}
+	for i, p := range points {
+		cs.Set(p, rune('A'+i))
+	}
+	var got []utils.Point[int]
+	cs.IterateOrdered(func(p utils.Point[int], v rune) bool {
+		got = append(got, p)
+		if i := slices.Index(points, p); v != rune('A'+i) {
+			t.Errorf("at %v: expected %c, got %c", p, 'A'+i, v)
+		}
+		return true
+	})
+	want := slices.Clone(points)
+	slices.SortFunc(want, func(a, b utils.Point[int]) int {
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+		return a.X - b.X
+	})
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	n := 0
+	cs.IterateOrdered(func(utils.Point[int], rune) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Errorf("expected iteration to stop early, got %d calls", n)
+	}
+}
+
+func TestChunkedStorageDropsChunks(t *testing.T) {
+	cs := NewChunkedStorage[int, rune]()
+	cs.Allocate(0, 0, '.')
+	cs.Set(utils.Point[int]{X: 1, Y: 1}, '#')
+	cs.Set(utils.Point[int]{X: 2, Y: 1}, '#')
+	cs.Set(utils.Point[int]{X: -1, Y: -1}, '#')
+	if cs.ChunkCount() != 2 || cs.Len() != 3 {
+		t.Fatalf("expected 3 points in 2 chunks, got %d in %d", cs.Len(), cs.ChunkCount())
+	}
+	cs.Delete(utils.Point[int]{X: -1, Y: -1})
+	if cs.ChunkCount() != 1 {
+		t.Errorf("expected Delete to drop an empty chunk, got %d chunks", cs.ChunkCount())
+	}
+	cs.Set(utils.Point[int]{X: 1, Y: 1}, '.')
+	if _, ok := cs.Get(utils.Point[int]{X: 1, Y: 1}); ok || cs.Len() != 1 {
+		t.Errorf("expected setting the empty value to delete the point")
+	}
+	cs.Set(utils.Point[int]{X: 2, Y: 1}, '.')
+	if cs.ChunkCount() != 0 || cs.Len() != 0 {
+		t.Errorf("expected setting the empty value to drop the chunk, got %d points in %d chunks", cs.Len(),
+			cs.ChunkCount())
+	}
+	cs.Set(utils.Point[int]{X: 7, Y: 7}, '.')
+	if cs.ChunkCount() != 0 {
+		t.Errorf("expected setting the empty value not to allocate a chunk")
+	}
+
+	b := NewRuneBoard[int](WithChunkedStorage[int, rune]())
+	err := b.FromStrings([]string{"#.", ".#"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Set(utils.Point[int]{X: 0, Y: 0}, '.')
+	b.Set(utils.Point[int]{X: 1, Y: 1}, '.')
+	if cs := b.storage.(*ChunkedStorage[int, rune]); cs.ChunkCount() != 0 {
+		t.Errorf("expected clearing a board by setting the empty value to drop its chunks")
+	}
+
+	ss := NewChunkedStorage[int, []int]()
+	ss.Allocate(0, 0, nil)
+	ss.Set(utils.Point[int]{X: 0, Y: 0}, nil)
+	if _, ok := ss.Get(utils.Point[int]{X: 0, Y: 0}); !ok {
+		t.Errorf("expected values that can't be compared to be stored")
+	}
+}
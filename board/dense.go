@@ -0,0 +1,204 @@
+package board
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/bits"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// DenseStorage is a BoardStorage backed by a single flat slice.  The allocated area has an arbitrary origin, so
+// negative coordinates work, and it grows automatically when a point outside it is set.
+type DenseStorage[KT constraints.Integer, VT any] struct {
+	data     []VT
+	present  []uint64
+	origin   utils.Point[KT]
+	width    int
+	height   int
+	emptyVal VT
+	count    int
+}
+
+// NewDenseStorage allocates and initializes a new DenseStorage
+func NewDenseStorage[KT constraints.Integer, VT any]() *DenseStorage[KT, VT] {
+	return &DenseStorage[KT, VT]{}
+}
+
+// WithDenseStorage provides a new DenseStorage backend to a Board.  Each board the option is applied to gets its
+// own storage.
+func WithDenseStorage[KT constraints.Integer, VT any]() func(*BoardOptions[KT, VT]) {
+	return func(options *BoardOptions[KT, VT]) {
+		options.storage = NewDenseStorage[KT, VT]()
+	}
+}
+
+// keyRange returns the smallest and largest values of KT, limited to what fits in an int
+func keyRange[KT constraints.Integer]() (int, int) {
+	var maxv KT = 1
+	for maxv<<1 > maxv {
+		maxv <<= 1
+	}
+	maxv |= maxv - 1
+	var zero KT
+	if zero-1 > zero {
+		if uint64(maxv) > math.MaxInt {
+			return 0, math.MaxInt
+		}
+		return 0, int(maxv)
+	}
+	return -int(maxv) - 1, int(maxv)
+}
+
+// Allocate discards all data and allocates an area of the given size, with its origin at (0, 0)
+func (ds *DenseStorage[KT, VT]) Allocate(width, height KT, emptyVal VT) {
+	ds.allocate(int(width), int(height), emptyVal)
+}
+
+// allocate is Allocate with int sizes, so that an area covering most of a small KT's range can be described
+func (ds *DenseStorage[KT, VT]) allocate(width, height int, emptyVal VT) {
+	ds.emptyVal = emptyVal
+	ds.origin = utils.Point[KT]{}
+	ds.width = width
+	ds.height = height
+	ds.data = make([]VT, ds.width*ds.height)
+	for i := range ds.data {
+		ds.data[i] = emptyVal
+	}
+	ds.present = make([]uint64, (len(ds.data)+63)/64)
+	ds.count = 0
+}
+
+// index returns the slice index of a point.  The bool is returned false if the point is outside the allocation.
+func (ds *DenseStorage[KT, VT]) index(p utils.Point[KT]) (int, bool) {
+	x := int(p.X) - int(ds.origin.X)
+	y := int(p.Y) - int(ds.origin.Y)
+	if x < 0 || y < 0 || x >= ds.width || y >= ds.height {
+		return 0, false
+	}
+	return y*ds.width + x, true
+}
+
+// grow enlarges the allocation to include a point, leaving headroom so that repeated growth is amortized.  The
+// headroom is clamped to the range of KT.
+func (ds *DenseStorage[KT, VT]) grow(p utils.Point[KT]) {
+	minX, minY := int(ds.origin.X), int(ds.origin.Y)
+	maxX, maxY := minX+ds.width-1, minY+ds.height-1
+	if ds.width == 0 || ds.height == 0 {
+		minX, minY, maxX, maxY = int(p.X), int(p.Y), int(p.X), int(p.Y)
+	}
+	padX := max(ds.width/2, 8)
+	padY := max(ds.height/2, 8)
+	if int(p.X) < minX {
+		minX = int(p.X) - padX
+	} else if int(p.X) > maxX {
+		maxX = int(p.X) + padX
+	}
+	if int(p.Y) < minY {
+		minY = int(p.Y) - padY
+	} else if int(p.Y) > maxY {
+		maxY = int(p.Y) + padY
+	}
+	lo, hi := keyRange[KT]()
+	minX, minY = max(minX, lo), max(minY, lo)
+	maxX, maxY = min(maxX, hi), min(maxY, hi)
+	nds := DenseStorage[KT, VT]{}
+	nds.allocate(maxX-minX+1, maxY-minY+1, ds.emptyVal)
+	nds.origin = utils.Point[KT]{X: KT(minX), Y: KT(minY)}
+	ds.Iterate(func(q utils.Point[KT], v VT) bool {
+		nds.Set(q, v)
+		return true
+	})
+	*ds = nds
+}
+
+// Set sets the value at a point, growing the allocation if needed
+func (ds *DenseStorage[KT, VT]) Set(p utils.Point[KT], v VT) {
+	i, ok := ds.index(p)
+	if !ok {
+		ds.grow(p)
+		i, ok = ds.index(p)
+		if !ok {
+			panic(fmt.Sprintf("DenseStorage: %v is outside the allocation after growing", p))
+		}
+	}
+	ds.data[i] = v
+	if ds.present[i/64]&(1<<(i%64)) == 0 {
+		ds.present[i/64] |= 1 << (i % 64)
+		ds.count++
+	}
+}
+
+// Get gets the value at a point
+func (ds *DenseStorage[KT, VT]) Get(p utils.Point[KT]) (VT, bool) {
+	i, ok := ds.index(p)
+	if !ok || ds.present[i/64]&(1<<(i%64)) == 0 {
+		var zv VT
+		return zv, false
+	}
+	return ds.data[i], true
+}
+
+// Delete removes the value at a point
+func (ds *DenseStorage[KT, VT]) Delete(p utils.Point[KT]) {
+	i, ok := ds.index(p)
+	if !ok || ds.present[i/64]&(1<<(i%64)) == 0 {
+		return
+	}
+	ds.present[i/64] &^= 1 << (i % 64)
+	ds.data[i] = ds.emptyVal
+	ds.count--
+}
+
+// GetOrDefault gets the value at a point, or a default value if no value is present
+func (ds *DenseStorage[KT, VT]) GetOrDefault(p utils.Point[KT], def VT) VT {
+	v, ok := ds.Get(p)
+	if ok {
+		return v
+	}
+	return def
+}
+
+// Len returns the number of points with values
+func (ds *DenseStorage[KT, VT]) Len() int {
+	return ds.count
+}
+
+// All returns an iterator over each point with a value, in row-major order
+func (ds *DenseStorage[KT, VT]) All() iter.Seq2[utils.Point[KT], VT] {
+	return func(yield func(utils.Point[KT], VT) bool) {
+		for w, word := range ds.present {
+			for word != 0 {
+				i := w*64 + bits.TrailingZeros64(word)
+				word &= word - 1
+				p := utils.Point[KT]{
+					X: ds.origin.X + KT(i%ds.width),
+					Y: ds.origin.Y + KT(i/ds.width),
+				}
+				if !yield(p, ds.data[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Iterate calls a function for each point with a value
+func (ds *DenseStorage[KT, VT]) Iterate(iterFunc func(p utils.Point[KT], v VT) bool) {
+	ds.All()(iterFunc)
+}
+
+// IterateOrdered calls a function for each point with a value, in row-major order
+func (ds *DenseStorage[KT, VT]) IterateOrdered(iterFunc func(p utils.Point[KT], v VT) bool) {
+	ds.All()(iterFunc)
+}
+
+// CopyToBoardStorage returns a copy as a BoardStorage type
+func (ds *DenseStorage[KT, VT]) CopyToBoardStorage() BoardStorage[KT, VT] {
+	nds := *ds
+	nds.data = append([]VT(nil), ds.data...)
+	nds.present = append([]uint64(nil), ds.present...)
+	return &nds
+}
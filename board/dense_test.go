@@ -0,0 +1,194 @@
+package board
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// benchSize is the width and height of the grid used in benchmarks, about the size of a typical puzzle input
+const benchSize = 140
+
+func benchStorageSet(b *testing.B, newStorage func() BoardStorage[int, rune]) {
+	b.ReportAllocs()
+	for range b.N {
+		s := newStorage()
+		for y := range benchSize {
+			for x := range benchSize {
+				s.Set(utils.Point[int]{X: x, Y: y}, '#')
+			}
+		}
+	}
+}
+
+// fullStorage returns a storage with every point of the benchmark grid set
+func fullStorage(newStorage func() BoardStorage[int, rune]) BoardStorage[int, rune] {
+	s := newStorage()
+	for y := range benchSize {
+		for x := range benchSize {
+			s.Set(utils.Point[int]{X: x, Y: y}, rune('a'+(x+y)%26))
+		}
+	}
+	return s
+}
+
+func benchStorageGet(b *testing.B, newStorage func() BoardStorage[int, rune]) {
+	s := fullStorage(newStorage)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var sum rune
+	for range b.N {
+		for y := range benchSize {
+			for x := range benchSize {
+				v, _ := s.Get(utils.Point[int]{X: x, Y: y})
+				sum += v
+			}
+		}
+	}
+	_ = sum
+}
+
+func benchStorageIterateOrdered(b *testing.B, newStorage func() BoardStorage[int, rune]) {
+	s := fullStorage(newStorage)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var sum rune
+	for range b.N {
+		s.IterateOrdered(func(_ utils.Point[int], v rune) bool {
+			sum += v
+			return true
+		})
+	}
+	_ = sum
+}
+
+func newDenseBench() BoardStorage[int, rune] {
+	return NewDenseStorage[int, rune]()
+}
+
+func newMap2DBench() BoardStorage[int, rune] {
+	return &Map2D[int, rune]{}
+}
+
+func BenchmarkDenseStorageSet(b *testing.B) {
+	benchStorageSet(b, newDenseBench)
+}
+
+func BenchmarkMap2DSet(b *testing.B) {
+	benchStorageSet(b, newMap2DBench)
+}
+
+func BenchmarkDenseStorageGet(b *testing.B) {
+	benchStorageGet(b, newDenseBench)
+}
+
+func BenchmarkMap2DGet(b *testing.B) {
+	benchStorageGet(b, newMap2DBench)
+}
+
+func BenchmarkDenseStorageIterateOrdered(b *testing.B) {
+	benchStorageIterateOrdered(b, newDenseBench)
+}
+
+func BenchmarkMap2DIterateOrdered(b *testing.B) {
+	benchStorageIterateOrdered(b, newMap2DBench)
+}
+
+func TestDenseStorageNegativeGrowth(t *testing.T) {
+	ds := NewDenseStorage[int, rune]()
+	points := []utils.Point[int]{
+		{X: 0, Y: 0},
+		{X: -20, Y: -30},
+		{X: 50, Y: -5},
+		{X: -3, Y: 40},
+		{X: -100, Y: 7},
+	}
+	for i, p := range points {
+		ds.Set(p, rune('A'+i))
+	}
+	for i, p := range points {
+		v, ok := ds.Get(p)
+		if !ok || v != rune('A'+i) {
+			t.Errorf("at %v: expected %c, got %c (present %v)", p, 'A'+i, v, ok)
+		}
+	}
+	if ds.Len() != len(points) {
+		t.Errorf("expected %d points, got %d", len(points), ds.Len())
+	}
+	for _, p := range []utils.Point[int]{{X: -1, Y: -1}, {X: -20, Y: -29}, {X: -1000, Y: -1000}} {
+		if _, ok := ds.Get(p); ok {
+			t.Errorf("expected no value at %v", p)
+		}
+	}
+	var order []utils.Point[int]
+	ds.IterateOrdered(func(p utils.Point[int], _ rune) bool {
+		order = append(order, p)
+		return true
+	})
+	want := []utils.Point[int]{{X: -20, Y: -30}, {X: 50, Y: -5}, {X: 0, Y: 0}, {X: -100, Y: 7}, {X: -3, Y: 40}}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, order)
+		}
+	}
+	ds.Delete(utils.Point[int]{X: -20, Y: -30})
+	if _, ok := ds.Get(utils.Point[int]{X: -20, Y: -30}); ok || ds.Len() != len(points)-1 {
+		t.Errorf("expected delete to remove the point")
+	}
+}
+
+func TestWithDenseStorageIsPerBoard(t *testing.T) {
+	opt := WithDenseStorage[int, rune]()
+	b1 := NewBoard[int, rune](opt)
+	b2 := NewBoard[int, rune](opt)
+	b1.Set(utils.Point[int]{X: 1, Y: 1}, '#')
+	if _, ok := b2.storage.Get(utils.Point[int]{X: 1, Y: 1}); ok {
+		t.Errorf("expected boards built from the same option not to share storage")
+	}
+}
+
+func TestDenseStorageSmallKeyType(t *testing.T) {
+	ds := NewDenseStorage[uint8, rune]()
+	ds.Set(utils.Point[uint8]{X: 200, Y: 200}, 'A')
+	ds.Set(utils.Point[uint8]{X: 0, Y: 0}, 'B')
+	ds.Set(utils.Point[uint8]{X: 255, Y: 0}, 'C')
+	for p, want := range map[utils.Point[uint8]]rune{{X: 200, Y: 200}: 'A', {X: 0, Y: 0}: 'B', {X: 255, Y: 0}: 'C'} {
+		if v, ok := ds.Get(p); !ok || v != want {
+			t.Errorf("at %v: expected %c, got %c (present %v)", p, want, v, ok)
+		}
+	}
+	if ds.Len() != 3 {
+		t.Errorf("expected 3 points, got %d", ds.Len())
+	}
+	ds8 := NewDenseStorage[int8, rune]()
+	ds8.Set(utils.Point[int8]{X: 100, Y: 100}, 'A')
+	ds8.Set(utils.Point[int8]{X: -128, Y: -128}, 'B')
+	if v, ok := ds8.Get(utils.Point[int8]{X: 100, Y: 100}); !ok || v != 'A' {
+		t.Errorf("expected A at (100, 100), got %c (present %v)", v, ok)
+	}
+	if v, ok := ds8.Get(utils.Point[int8]{X: -128, Y: -128}); !ok || v != 'B' {
+		t.Errorf("expected B at (-128, -128), got %c (present %v)", v, ok)
+	}
+}
+
+func TestKeyRange(t *testing.T) {
+	check := func(name string, lo, hi, wantLo, wantHi int) {
+		if lo != wantLo || hi != wantHi {
+			t.Errorf("%s: expected [%d, %d], got [%d, %d]", name, wantLo, wantHi, lo, hi)
+		}
+	}
+	lo, hi := keyRange[int8]()
+	check("int8", lo, hi, -128, 127)
+	lo, hi = keyRange[uint8]()
+	check("uint8", lo, hi, 0, 255)
+	lo, hi = keyRange[int16]()
+	check("int16", lo, hi, -32768, 32767)
+	lo, hi = keyRange[int]()
+	check("int", lo, hi, math.MinInt, math.MaxInt)
+	lo, hi = keyRange[uint64]()
+	check("uint64", lo, hi, 0, math.MaxInt)
+}
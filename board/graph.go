@@ -0,0 +1,82 @@
+package board
+
+import (
+	"github.com/ghjm/advent_utils"
+	"github.com/ghjm/advent_utils/graph"
+	"golang.org/x/exp/constraints"
+)
+
+// ToGraph returns an undirected graph with a node for every passable cell within the board's bounds, and an edge
+// of cost 1 between each pair of passable neighbors.  If neighbors is nil, the cardinal neighbors are used.
+func ToGraph[KT constraints.Integer, VT any](b *Board[KT, VT], passable func(p utils.Point[KT], v VT) bool, neighbors func(p utils.Point[KT]) []utils.Point[KT]) *graph.UndirectedGraph[utils.Point[KT]] {
+	if neighbors == nil {
+		neighbors = func(p utils.Point[KT]) []utils.Point[KT] {
+			return b.Cardinals(p, false)
+		}
+	}
+	g := &graph.UndirectedGraph[utils.Point[KT]]{}
+	done := make(map[utils.Point[KT]]struct{})
+	for p := range b.InBounds() {
+		if !passable(p, b.Get(p)) {
+			continue
+		}
+		g.AddNode(p)
+		done[p] = struct{}{}
+		for _, np := range neighbors(p) {
+			if _, ok := done[np]; ok {
+				continue
+			}
+			if b.Contains(np) && passable(np, b.Get(np)) {
+				g.AddEdge(p, np, 1)
+			}
+		}
+	}
+	return g
+}
+
+// ToDirectedGraph returns a directed graph with a node for every cell within the board's bounds, and an edge of
+// cost 1 for every move from a cell to a neighbor that canMove allows.  If neighbors is nil, the cardinal neighbors
+// are used.
+func ToDirectedGraph[KT constraints.Integer, VT any](b *Board[KT, VT], canMove func(from, to utils.Point[KT], fromV, toV VT) bool, neighbors func(p utils.Point[KT]) []utils.Point[KT]) *graph.DirectedGraph[utils.Point[KT]] {
+	if neighbors == nil {
+		neighbors = func(p utils.Point[KT]) []utils.Point[KT] {
+			return b.Cardinals(p, false)
+		}
+	}
+	g := &graph.DirectedGraph[utils.Point[KT]]{}
+	for p := range b.InBounds() {
+		g.AddNode(p)
+		v := b.Get(p)
+		for _, np := range neighbors(p) {
+			if b.Contains(np) && canMove(p, np, v, b.Get(np)) {
+				g.AddEdge(p, np, 1)
+			}
+		}
+	}
+	return g
+}
+
+// slopeDirections maps slope runes to the direction they can be walked in
+var slopeDirections = map[rune]utils.StdPoint{
+	'^': {X: 0, Y: -1},
+	'>': {X: 1, Y: 0},
+	'v': {X: 0, Y: 1},
+	'<': {X: -1, Y: 0},
+}
+
+// SlopeMove is a canMove function for ToDirectedGraph on rune boards where '#' is a wall and the slopes ^ > v <
+// can only be crossed in the direction they point.  Moves into a slope against its direction, and moves off a
+// slope in any other direction, are not allowed.
+func SlopeMove[KT constraints.Integer](from, to utils.Point[KT], fromV, toV rune) bool {
+	if fromV == '#' || toV == '#' {
+		return false
+	}
+	d := utils.StdPoint{X: int(to.X) - int(from.X), Y: int(to.Y) - int(from.Y)}
+	if sd, ok := slopeDirections[fromV]; ok && sd != d {
+		return false
+	}
+	if sd, ok := slopeDirections[toV]; ok && sd.Negate() == d {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+func TestToDirectedGraphSlopes(t *testing.T) {
+	b := NewRuneBoard[int]()
+	err := b.FromStrings([]string{
+		"#.#",
+		".>.",
+		"#v#",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := ToDirectedGraph(&b.Board, SlopeMove[int], nil)
+	if len(g.Nodes) != 9 {
+		t.Errorf("expected a node for all 9 cells, got %d", len(g.Nodes))
+	}
+	wall := utils.Point[int]{X: 0, Y: 0}
+	if edges, ok := g.Nodes[wall]; !ok || len(edges) != 0 {
+		t.Errorf("expected the wall to be a node with no edges, got %v (present %v)", edges, ok)
+	}
+	slope := utils.Point[int]{X: 1, Y: 1}
+	edges := g.Nodes[slope]
+	if len(edges) != 1 || edges[0].Dest != (utils.Point[int]{X: 2, Y: 1}) {
+		t.Errorf("expected the slope to only lead right, got %v", edges)
+	}
+	down := utils.Point[int]{X: 1, Y: 2}
+	for _, e := range g.Nodes[down] {
+		if e.Dest == slope {
+			t.Errorf("expected no move up off a down slope")
+		}
+	}
+}
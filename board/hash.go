@@ -0,0 +1,123 @@
+package board
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// splitmix64 is the finalizer of the SplitMix64 generator, used to scramble the bits of a hash
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// hashString returns the 64-bit FNV-1a hash of a string
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// boardHasher is implemented by values that hash only part of themselves, such as RunePlusData, whose extra
+// data is ignored by the default comparison
+type boardHasher interface {
+	boardHash() uint64
+}
+
+// boardHash returns a hash of the rune only, since RunePlusBoard compares cells by their runes
+func (d RunePlusData[ET]) boardHash() uint64 {
+	return uint64(d.Value)
+}
+
+// hashValue returns a 64-bit hash of a cell value.  Common types are hashed directly, and anything else is
+// hashed by its HashString method if it is Hashable, or by its printed representation.
+func hashValue(v any) uint64 {
+	switch tv := v.(type) {
+	case bool:
+		if tv {
+			return 1
+		}
+		return 0
+	case int:
+		return uint64(tv)
+	case int8:
+		return uint64(tv)
+	case int16:
+		return uint64(tv)
+	case int32:
+		return uint64(tv)
+	case int64:
+		return uint64(tv)
+	case uint:
+		return uint64(tv)
+	case uint8:
+		return uint64(tv)
+	case uint16:
+		return uint64(tv)
+	case uint32:
+		return uint64(tv)
+	case uint64:
+		return tv
+	case string:
+		return hashString(tv)
+	case boardHasher:
+		return tv.boardHash()
+	case Hashable:
+		return hashString(tv.HashString())
+	}
+	return hashString(fmt.Sprintf("%#v", v))
+}
+
+// hashPoint returns a 64-bit hash of a point
+func hashPoint[KT constraints.Integer](p utils.Point[KT]) uint64 {
+	return splitmix64(uint64(p.X)) ^ splitmix64(uint64(p.Y)<<1|1)
+}
+
+// Hash returns a 64-bit structural hash of the board's bounds and contents.  Cells holding the empty value hash
+// the same as unset cells, and the result does not depend on the storage type or iteration order.  Only the rune
+// of a RunePlusData is hashed, matching the default comparison of a RunePlusBoard, so boards that Compare equal
+// have the same hash unless a custom comparison function ignores part of the values.
+func (b *Board[KT, VT]) Hash() uint64 {
+	var h uint64
+	if b.bounds != nil {
+		r := b.Bounds()
+		h = splitmix64(hashPoint(r.P1) ^ splitmix64(hashPoint(r.P2)))
+	}
+	for p, v := range b.All() {
+		if b.compFunc != nil && b.compFunc(v, b.emptyVal) {
+			continue
+		}
+		h += splitmix64(hashPoint(p) ^ splitmix64(hashValue(v)))
+	}
+	return h
+}
+
+// sameBoard confirms that two boards with the same hash are really the same.  Boards without a comparison
+// function are compared by hash alone.
+func sameBoard[KT constraints.Integer, VT any](b1, b2 *Board[KT, VT]) bool {
+	return b1.compFunc == nil || b1.Compare(b2)
+}
+
+// findBoardCycle looks for a repeated board state, taking at most limit steps if limit is not negative
+func findBoardCycle[KT constraints.Integer, VT any](b *Board[KT, VT], step func(*Board[KT, VT]) *Board[KT, VT], limit int) utils.Cycle[*Board[KT, VT]] {
+	return utils.FindCycleFunc(b, step, (*Board[KT, VT]).Hash, sameBoard[KT, VT], limit)
+}
+
+// FindBoardCycle repeatedly applies a step function to a board until its state repeats, comparing boards by
+// Hash and confirming matches with Compare.  The step function must return a new board rather than modifying its
+// argument.
+func FindBoardCycle[KT constraints.Integer, VT any](b *Board[KT, VT], step func(*Board[KT, VT]) *Board[KT, VT]) utils.Cycle[*Board[KT, VT]] {
+	return findBoardCycle(b, step, -1)
+}
+
+// BoardAt returns the board after n applications of a step function.  If the board's state repeats before step n
+// is reached, the rest of the steps are skipped by extrapolating the cycle.  The step function must return a new
+// board rather than modifying its argument.
+func BoardAt[KT constraints.Integer, VT any](b *Board[KT, VT], step func(*Board[KT, VT]) *Board[KT, VT], n int) *Board[KT, VT] {
+	return findBoardCycle(b, step, n).At(n)
+}
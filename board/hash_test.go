@@ -0,0 +1,68 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// shiftRight returns a copy of a wrapping board with every cell moved one place to the right
+func shiftRight(b *Board[int, rune]) *Board[int, rune] {
+	nb := b.newLike(b.Bounds())
+	for p, v := range b.All() {
+		nb.Set(p.Add(utils.Point[int]{X: 1}), v)
+	}
+	return nb
+}
+
+func TestBoardAt(t *testing.T) {
+	b := NewRuneBoard[int](WithWrap[int, rune]())
+	err := b.FromStrings([]string{"#....", ".#..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := 0
+	step := func(b *Board[int, rune]) *Board[int, rune] {
+		steps++
+		return shiftRight(b)
+	}
+	got := BoardAt(&b.Board, step, 1_000_003)
+	want := []string{"...#.", "....#"}
+	if f := got.Format(func(r rune) rune { return r }); f[0] != want[0] || f[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, f)
+	}
+	if steps != 5 {
+		t.Errorf("expected 5 steps to find the cycle, got %d", steps)
+	}
+	steps = 0
+	BoardAt(&b.Board, step, 2)
+	if steps != 2 {
+		t.Errorf("expected BoardAt to stop after 2 steps, got %d", steps)
+	}
+	c := FindBoardCycle(&b.Board, shiftRight)
+	if c.Start != 0 || c.Length != 5 {
+		t.Errorf("expected a cycle of length 5 starting at 0, got %d/%d", c.Start, c.Length)
+	}
+}
+
+func TestRunePlusBoardHash(t *testing.T) {
+	b1 := NewRunePlusBoard[int, int]()
+	b2 := NewRunePlusBoard[int, int]()
+	for _, b := range []*RunePlusBoard[int, int]{b1, b2} {
+		err := b.FromStrings([]string{"#.", ".#"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	b1.SetExtra(utils.Point[int]{X: 0, Y: 0}, 7)
+	if !b1.Compare(&b2.Board) {
+		t.Fatalf("expected boards differing only in extra data to compare equal")
+	}
+	if b1.Hash() != b2.Hash() {
+		t.Errorf("expected boards that compare equal to hash the same")
+	}
+	b2.SetRune(utils.Point[int]{X: 1, Y: 0}, '#')
+	if b1.Hash() == b2.Hash() {
+		t.Errorf("expected boards with different runes to hash differently")
+	}
+}
@@ -0,0 +1,148 @@
+package board
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// Overlay is a set of points drawn in a single color over a rendered board, such as a path or a highlighted region
+type Overlay[KT constraints.Integer] struct {
+	Points []utils.Point[KT]
+	Color  color.Color
+}
+
+// RenderImage draws the area within the board's bounds as an image, with each cell a square of cellSize pixels
+// colored by colorFunc.  Overlays are drawn over the cells in order, and may be translucent.
+func (b *Board[KT, VT]) RenderImage(colorFunc func(p utils.Point[KT], v VT) color.Color, cellSize int, overlays ...Overlay[KT]) *image.RGBA {
+	r := b.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, int(r.Width())*cellSize, int(r.Height())*cellSize))
+	if b.bounds == nil {
+		return img
+	}
+	cellRect := func(p utils.Point[KT]) image.Rectangle {
+		x := (int(p.X) - int(r.P1.X)) * cellSize
+		y := (int(p.Y) - int(r.P1.Y)) * cellSize
+		return image.Rect(x, y, x+cellSize, y+cellSize)
+	}
+	for p := range b.InBounds() {
+		draw.Draw(img, cellRect(p), image.NewUniform(colorFunc(p, b.Get(p))), image.Point{}, draw.Src)
+	}
+	for _, o := range overlays {
+		u := image.NewUniform(o.Color)
+		for _, p := range o.Points {
+			if p.Within(r) {
+				draw.Draw(img, cellRect(p), u, image.Point{}, draw.Over)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG renders the board as with RenderImage, and writes it to w in PNG format
+func (b *Board[KT, VT]) WritePNG(w io.Writer, colorFunc func(p utils.Point[KT], v VT) color.Color, cellSize int, overlays ...Overlay[KT]) error {
+	return png.Encode(w, b.RenderImage(colorFunc, cellSize, overlays...))
+}
+
+// GIFRecorderOptions collects options when initializing a GIFRecorder
+type GIFRecorderOptions struct {
+	delay     int
+	loopCount int
+}
+
+// WithFrameDelay sets the time each frame is shown, in hundredths of a second.  The default is 10.
+func WithFrameDelay(delay int) func(*GIFRecorderOptions) {
+	return func(options *GIFRecorderOptions) {
+		options.delay = delay
+	}
+}
+
+// WithLoopCount sets how many times the animation repeats.  0, the default, repeats forever, and -1 plays once.
+func WithLoopCount(loopCount int) func(*GIFRecorderOptions) {
+	return func(options *GIFRecorderOptions) {
+		options.loopCount = loopCount
+	}
+}
+
+// GIFRecorder collects rendered boards, such as the steps of a simulation, as the frames of an animated GIF
+type GIFRecorder[KT constraints.Integer, VT any] struct {
+	GIFRecorderOptions
+	colorFunc func(p utils.Point[KT], v VT) color.Color
+	cellSize  int
+	frames    []*image.RGBA
+}
+
+// NewGIFRecorder allocates and initializes a new GIFRecorder, which renders frames using colorFunc and cellSize
+// as in RenderImage
+func NewGIFRecorder[KT constraints.Integer, VT any](colorFunc func(p utils.Point[KT], v VT) color.Color, cellSize int, options ...func(*GIFRecorderOptions)) *GIFRecorder[KT, VT] {
+	g := &GIFRecorder[KT, VT]{
+		GIFRecorderOptions: GIFRecorderOptions{
+			delay: 10,
+		},
+		colorFunc: colorFunc,
+		cellSize:  cellSize,
+	}
+	for _, opt := range options {
+		opt(&g.GIFRecorderOptions)
+	}
+	return g
+}
+
+// AddFrame renders the current state of a board, with optional overlays, as the next frame
+func (g *GIFRecorder[KT, VT]) AddFrame(b *Board[KT, VT], overlays ...Overlay[KT]) {
+	g.frames = append(g.frames, b.RenderImage(g.colorFunc, g.cellSize, overlays...))
+}
+
+// Len returns the number of frames recorded so far
+func (g *GIFRecorder[KT, VT]) Len() int {
+	return len(g.frames)
+}
+
+// buildPalette returns a palette for the recorded frames.  If they use 256 colors or fewer, the palette holds exactly
+// those colors.  Otherwise, the Plan 9 palette is used.
+func (g *GIFRecorder[KT, VT]) buildPalette() color.Palette {
+	seen := make(map[color.RGBA]struct{})
+	var pal color.Palette
+	for _, f := range g.frames {
+		for i := 0; i < len(f.Pix); i += 4 {
+			c := color.RGBA{R: f.Pix[i], G: f.Pix[i+1], B: f.Pix[i+2], A: f.Pix[i+3]}
+			if _, ok := seen[c]; ok {
+				continue
+			}
+			if len(seen) == 256 {
+				return palette.Plan9
+			}
+			seen[c] = struct{}{}
+			pal = append(pal, c)
+		}
+	}
+	if len(pal) == 0 {
+		pal = append(pal, color.RGBA{A: 0xff})
+	}
+	return pal
+}
+
+// Write encodes the recorded frames to w as an animated GIF
+func (g *GIFRecorder[KT, VT]) Write(w io.Writer) error {
+	pal := g.buildPalette()
+	anim := gif.GIF{
+		LoopCount: g.loopCount,
+	}
+	for _, f := range g.frames {
+		pf := image.NewPaletted(f.Bounds(), pal)
+		draw.Draw(pf, pf.Rect, f, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, pf)
+		anim.Delay = append(anim.Delay, g.delay)
+		anim.Config.Width = max(anim.Config.Width, f.Rect.Dx())
+		anim.Config.Height = max(anim.Config.Height, f.Rect.Dy())
+	}
+	anim.Config.ColorModel = pal
+	return gif.EncodeAll(w, &anim)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"github.com/ghjm/advent_utils"
 	"golang.org/x/exp/constraints"
+	"iter"
 	"sort"
 )
 
@@ -87,15 +88,22 @@ func (m2 *Map2D[KT, VT]) Len() int {
 	return len(m2.data)
 }
 
-// Iterate calls a function for each non-empty point present in the map
-func (m2 *Map2D[KT, VT]) Iterate(iterFunc func(p utils.Point[KT], v VT) bool) {
-	for k, v := range m2.data {
-		if !iterFunc(k, v) {
-			return
+// All returns an iterator over each non-empty point present in the map
+func (m2 *Map2D[KT, VT]) All() iter.Seq2[utils.Point[KT], VT] {
+	return func(yield func(utils.Point[KT], VT) bool) {
+		for k, v := range m2.data {
+			if !yield(k, v) {
+				return
+			}
 		}
 	}
 }
 
+// Iterate calls a function for each non-empty point present in the map
+func (m2 *Map2D[KT, VT]) Iterate(iterFunc func(p utils.Point[KT], v VT) bool) {
+	m2.All()(iterFunc)
+}
+
 // IterateOrdered calls a function for each non-empty point present in the map, in a deterministic order
 func (m2 *Map2D[KT, VT]) IterateOrdered(iterFunc func(p utils.Point[KT], v VT) bool) {
 	type tuple = struct {
@@ -0,0 +1,198 @@
+package board
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// font4x6 is the small letter font, with glyphs 4 cells wide (except I and Y) and 6 tall
+var font4x6 = map[rune][]string{
+	'A': {".##.", "#..#", "#..#", "####", "#..#", "#..#"},
+	'B': {"###.", "#..#", "###.", "#..#", "#..#", "###."},
+	'C': {".##.", "#..#", "#...", "#...", "#..#", ".##."},
+	'E': {"####", "#...", "###.", "#...", "#...", "####"},
+	'F': {"####", "#...", "###.", "#...", "#...", "#..."},
+	'G': {".##.", "#..#", "#...", "#.##", "#..#", ".###"},
+	'H': {"#..#", "#..#", "####", "#..#", "#..#", "#..#"},
+	'I': {"###", ".#.", ".#.", ".#.", ".#.", "###"},
+	'J': {"..##", "...#", "...#", "...#", "#..#", ".##."},
+	'K': {"#..#", "#.#.", "##..", "#.#.", "#.#.", "#..#"},
+	'L': {"#...", "#...", "#...", "#...", "#...", "####"},
+	'O': {".##.", "#..#", "#..#", "#..#", "#..#", ".##."},
+	'P': {"###.", "#..#", "#..#", "###.", "#...", "#..."},
+	'R': {"###.", "#..#", "#..#", "###.", "#.#.", "#..#"},
+	'S': {".###", "#...", "#...", ".##.", "...#", "###."},
+	'U': {"#..#", "#..#", "#..#", "#..#", "#..#", ".##."},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#.."},
+	'Z': {"####", "...#", "..#.", ".#..", "#...", "####"},
+}
+
+// font6x10 is the large letter font, with glyphs 6 cells wide and 10 tall
+var font6x10 = map[rune][]string{
+	'A': {"..##..", ".#..#.", "#....#", "#....#", "#....#", "######", "#....#", "#....#", "#....#", "#....#"},
+	'B': {"#####.", "#....#", "#....#", "#....#", "#####.", "#....#", "#....#", "#....#", "#....#", "#####."},
+	'C': {".####.", "#....#", "#.....", "#.....", "#.....", "#.....", "#.....", "#.....", "#....#", ".####."},
+	'E': {"######", "#.....", "#.....", "#.....", "#####.", "#.....", "#.....", "#.....", "#.....", "######"},
+	'F': {"######", "#.....", "#.....", "#.....", "#####.", "#.....", "#.....", "#.....", "#.....", "#....."},
+	'G': {".####.", "#....#", "#.....", "#.....", "#.....", "#..###", "#....#", "#....#", "#...##", ".###.#"},
+	'H': {"#....#", "#....#", "#....#", "#....#", "######", "#....#", "#....#", "#....#", "#....#", "#....#"},
+	'J': {"...###", "....#.", "....#.", "....#.", "....#.", "....#.", "....#.", "#...#.", "#...#.", ".###.."},
+	'K': {"#....#", "#...#.", "#..#..", "#.#...", "##....", "##....", "#.#...", "#..#..", "#...#.", "#....#"},
+	'L': {"#.....", "#.....", "#.....", "#.....", "#.....", "#.....", "#.....", "#.....", "#.....", "######"},
+	'N': {"#....#", "##...#", "##...#", "#.#..#", "#.#..#", "#..#.#", "#..#.#", "#...##", "#...##", "#....#"},
+	'P': {"#####.", "#....#", "#....#", "#....#", "#####.", "#.....", "#.....", "#.....", "#.....", "#....."},
+	'R': {"#####.", "#....#", "#....#", "#....#", "#####.", "#..#..", "#...#.", "#...#.", "#....#", "#....#"},
+	'X': {"#....#", "#....#", ".#..#.", ".#..#.", "..##..", "..##..", ".#..#.", ".#..#.", "#....#", "#....#"},
+	'Z': {"######", ".....#", ".....#", "....#.", "...#..", "..#...", ".#....", "#.....", "#.....", "######"},
+}
+
+// glyphs maps the text of every known glyph to its letter
+var glyphs = func() map[string]rune {
+	m := make(map[string]rune)
+	for _, font := range []map[rune][]string{font4x6, font6x10} {
+		for r, g := range font {
+			m[strings.Join(g, "\n")] = r
+		}
+	}
+	return m
+}()
+
+// glyphWidths lists the widths of the known glyphs of each height, for splitting letters that touch
+var glyphWidths = func() map[int][]int {
+	m := make(map[int][]int)
+	for _, font := range []map[rune][]string{font4x6, font6x10} {
+		for _, g := range font {
+			if !slices.Contains(m[len(g)], len(g[0])) {
+				m[len(g)] = append(m[len(g)], len(g[0]))
+			}
+		}
+	}
+	for _, widths := range m {
+		slices.Sort(widths)
+	}
+	return m
+}()
+
+// splitGlyphs reads a run of columns with no gaps as a sequence of known glyphs.  Usually the run is a single
+// glyph, but a letter that fills its whole cell, like Y in the 4x6 font, touches the next letter.  The bool is
+// returned false if the run can't be made of known glyphs.
+func splitGlyphs(rows []string) ([]rune, bool) {
+	if r, ok := glyphs[strings.Join(rows, "\n")]; ok {
+		return []rune{r}, true
+	}
+	width := len(rows[0])
+	for _, w := range glyphWidths[len(rows)] {
+		if w >= width {
+			break
+		}
+		head := make([]string, len(rows))
+		tail := make([]string, len(rows))
+		for i, row := range rows {
+			head[i], tail[i] = row[:w], row[w:]
+		}
+		r, ok := glyphs[strings.Join(head, "\n")]
+		if !ok {
+			continue
+		}
+		if rest, ok := splitGlyphs(tail); ok {
+			return append([]rune{r}, rest...), true
+		}
+	}
+	return nil, false
+}
+
+// OCRError is returned when OCR finds glyphs that are not in any known font
+type OCRError struct {
+	// Text is the recognized text, with a ? in place of each unknown glyph
+	Text string
+	// Unknown are the unknown glyphs, drawn with # and .
+	Unknown []string
+}
+
+// Error returns the error string
+func (e *OCRError) Error() string {
+	return fmt.Sprintf("unrecognized glyphs in %q:\n%s", e.Text, strings.Join(e.Unknown, "\n\n"))
+}
+
+// OCRFunc reads capital letters drawn on a board, where isLit reports which values are part of a letter.  The
+// board is trimmed to the area with anything lit, and split into glyphs at columns with nothing lit, or between
+// known glyphs where letters touch.  The 4x6 and 6x10 letter fonts are recognized.
+func OCRFunc[KT constraints.Integer, VT any](b *Board[KT, VT], isLit func(VT) bool) (string, error) {
+	var lit utils.Rectangle[KT]
+	found := false
+	for p := range b.InBounds() {
+		if !isLit(b.Get(p)) {
+			continue
+		}
+		if !found {
+			lit = utils.Rectangle[KT]{P1: p, P2: p}
+			found = true
+		}
+		lit.P1.X, lit.P2.X = min(lit.P1.X, p.X), max(lit.P2.X, p.X)
+		lit.P1.Y, lit.P2.Y = min(lit.P1.Y, p.Y), max(lit.P2.Y, p.Y)
+	}
+	if !found {
+		return "", nil
+	}
+	columnLit := func(x KT) bool {
+		for y := lit.P1.Y; y <= lit.P2.Y; y++ {
+			if isLit(b.Get(utils.Point[KT]{X: x, Y: y})) {
+				return true
+			}
+		}
+		return false
+	}
+	var text strings.Builder
+	var unknown []string
+	for x := lit.P1.X; x <= lit.P2.X; x++ {
+		if !columnLit(x) {
+			continue
+		}
+		start := x
+		for x <= lit.P2.X && columnLit(x) {
+			x++
+		}
+		rows := make([]string, 0, lit.Height())
+		for y := lit.P1.Y; y <= lit.P2.Y; y++ {
+			var row strings.Builder
+			for gx := start; gx < x; gx++ {
+				if isLit(b.Get(utils.Point[KT]{X: gx, Y: y})) {
+					row.WriteRune('#')
+				} else {
+					row.WriteRune('.')
+				}
+			}
+			rows = append(rows, row.String())
+		}
+		letters, ok := splitGlyphs(rows)
+		if !ok {
+			letters = []rune{'?'}
+			unknown = append(unknown, strings.Join(rows, "\n"))
+		}
+		text.WriteString(string(letters))
+	}
+	if len(unknown) > 0 {
+		return text.String(), &OCRError{Text: text.String(), Unknown: unknown}
+	}
+	return text.String(), nil
+}
+
+// OCR reads capital letters drawn on a board with a given rune, as with OCRFunc
+func OCR[KT constraints.Integer](b *RuneBoard[KT], lit rune) (string, error) {
+	return OCRFunc(&b.Board, func(r rune) bool {
+		return r == lit
+	})
+}
+
+// MustOCR reads capital letters drawn on a board with a given rune, and panics on any error
+func MustOCR[KT constraints.Integer](b *RuneBoard[KT], lit rune) string {
+	s, err := OCR(b, lit)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package board
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOCR(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{
+			// a 40 wide screen of 4x6 letters, each followed by a blank column
+			name: "4x6",
+			lines: []string{
+				"###...##..#....###..###..####..##..#..#.",
+				"#..#.#..#.#....#..#.#..#....#.#..#.#..#.",
+				"#..#.#....#....#..#.###....#..#..#.#..#.",
+				"###..#.##.#....###..#..#..#...####.#..#.",
+				"#.#..#..#.#....#.#..#..#.#....#..#.#..#.",
+				"#..#..###.####.#..#.###..####.#..#..##..",
+			},
+			want: "RGLRBZAU",
+		},
+		{
+			// a 50 wide screen of 5 column cells, where Y fills its cell and touches the next letter
+			name: "4x6 touching",
+			lines: []string{
+				"####.####.####.#...##..#.####.###..###....##.#...#",
+				"#....#....#....#...##.#..#....#..#..#......#.#...#",
+				"###..###..###...#.#.##...###..#..#..#......#..#.#.",
+				"#....#....#......#..#.#..#....###...#......#...#..",
+				"#....#....#......#..#.#..#....#.#...#...#..#...#..",
+				"####.#....####...#..#..#.#....#..#.###...##....#..",
+			},
+			want: "EFEYKFRIJY",
+		},
+		{
+			// 6x10 letters two columns apart, with a margin of empty cells around them
+			name: "6x10",
+			lines: []string{
+				"....................................................................",
+				"..######..#####....####...#....#..#.........##.......###..#.........",
+				"..#.......#....#..#....#..#....#..#........#..#.......#...#.........",
+				"..#.......#....#..#........#..#...#.......#....#......#...#.........",
+				"..#.......#....#..#........#..#...#.......#....#......#...#.........",
+				"..#####...#####...#.........##....#.......#....#......#...#.........",
+				"..#.......#..#....#.........##....#.......######......#...#.........",
+				"..#.......#...#...#........#..#...#.......#....#......#...#.........",
+				"..#.......#...#...#........#..#...#.......#....#..#...#...#.........",
+				"..#.......#....#..#....#..#....#..#.......#....#..#...#...#.........",
+				"..######..#....#...####...#....#..######..#....#...###....######....",
+				"....................................................................",
+			},
+			want: "ERCXLAJL",
+		},
+		{
+			name:  "empty",
+			lines: []string{"....", "...."},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewRuneBoard[int]()
+			b.MustFromStrings(tt.lines)
+			got, err := OCR(b, '#')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOCRUnknown(t *testing.T) {
+	b := NewRuneBoard[int]()
+	b.MustFromStrings([]string{
+		"#..#.#...#..#..#",
+		"#..#.#...#..#..#",
+		"####.#..###.####",
+		"#..#.#...#..#..#",
+		"#..#.#...#..#..#",
+		"#..#.#...#..#..#",
+	})
+	got, err := OCR(b, '#')
+	var oe *OCRError
+	if !errors.As(err, &oe) {
+		t.Fatalf("expected an OCRError, got %v", err)
+	}
+	if got != "H??H" || oe.Text != got {
+		t.Errorf("expected H??H, got %q and %q", got, oe.Text)
+	}
+	if len(oe.Unknown) != 2 || oe.Unknown[0] != "#\n#\n#\n#\n#\n#" {
+		t.Errorf("unexpected unknown glyphs %q", oe.Unknown)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustOCR to panic")
+		}
+	}()
+	MustOCR(b, '#')
+}
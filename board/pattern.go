@@ -0,0 +1,150 @@
+package board
+
+import (
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// PatternOptions collects options for FindPattern
+type PatternOptions[VT any] struct {
+	wildcard     *VT
+	orientations bool
+	noOverlap    bool
+}
+
+// WithWildcard sets a pattern value that matches anything on the board
+func WithWildcard[VT any](wildcard VT) func(*PatternOptions[VT]) {
+	return func(options *PatternOptions[VT]) {
+		options.wildcard = &wildcard
+	}
+}
+
+// WithAllOrientations also matches the pattern rotated and flipped into each of its eight orientations
+func WithAllOrientations[VT any]() func(*PatternOptions[VT]) {
+	return func(options *PatternOptions[VT]) {
+		options.orientations = true
+	}
+}
+
+// WithoutOverlap only returns matches that do not share any non-wildcard cells with an earlier match
+func WithoutOverlap[VT any]() func(*PatternOptions[VT]) {
+	return func(options *PatternOptions[VT]) {
+		options.noOverlap = true
+	}
+}
+
+// PatternMatch is a place where a pattern was found on a board
+type PatternMatch[KT constraints.Integer] struct {
+	// Offset is the board position of the top left corner of the (oriented) pattern
+	Offset utils.Point[KT]
+	// Orientation is the orientation the pattern was matched in
+	Orientation Orientation
+	// Points are the board positions of the pattern's non-wildcard cells
+	Points []utils.Point[KT]
+}
+
+// patternCell is a single non-wildcard cell of a pattern, relative to the pattern's top left corner
+type patternCell[KT constraints.Integer, VT any] struct {
+	p utils.Point[KT]
+	v VT
+}
+
+// orientedPattern is a pattern in one orientation, reduced to its size and non-wildcard cells
+type orientedPattern[KT constraints.Integer, VT any] struct {
+	orientation Orientation
+	width       KT
+	height      KT
+	cells       []patternCell[KT, VT]
+}
+
+// FindPattern returns every place on the board where a pattern occurs.  Every cell within the pattern's bounds
+// must match, including empty ones, unless it holds the wildcard value.  Values are compared using the board's
+// compare function.  Matches are returned in row-major order of their offsets.
+func (b *Board[KT, VT]) FindPattern(pattern *Board[KT, VT], options ...func(*PatternOptions[VT])) []PatternMatch[KT] {
+	if b.compFunc == nil {
+		panic("compFunc not defined")
+	}
+	var opts PatternOptions[VT]
+	for _, opt := range options {
+		opt(&opts)
+	}
+	orientations := []Orientation{OrientIdentity}
+	if opts.orientations {
+		orientations = AllOrientations
+	}
+	var patterns []orientedPattern[KT, VT]
+	var seen []*Board[KT, VT]
+	for _, o := range orientations {
+		pb := pattern.Orient(o)
+		dup := false
+		for _, sb := range seen {
+			if sb.Compare(pb) {
+				dup = true
+				break
+			}
+		}
+		if dup {
+			continue
+		}
+		seen = append(seen, pb)
+		r := pb.Bounds()
+		op := orientedPattern[KT, VT]{
+			orientation: o,
+			width:       r.Width(),
+			height:      r.Height(),
+		}
+		for p := range pb.InBounds() {
+			v := pb.Get(p)
+			if opts.wildcard != nil && b.compFunc(v, *opts.wildcard) {
+				continue
+			}
+			op.cells = append(op.cells, patternCell[KT, VT]{
+				p: utils.Point[KT]{X: p.X - r.P1.X, Y: p.Y - r.P1.Y},
+				v: v,
+			})
+		}
+		patterns = append(patterns, op)
+	}
+	var results []PatternMatch[KT]
+	used := make(map[utils.Point[KT]]struct{})
+	r := b.Bounds()
+	for y := r.P1.Y; y <= r.P2.Y; y++ {
+		for x := r.P1.X; x <= r.P2.X; x++ {
+			for _, op := range patterns {
+				if x+op.width-1 > r.P2.X || y+op.height-1 > r.P2.Y {
+					continue
+				}
+				match := PatternMatch[KT]{
+					Offset:      utils.Point[KT]{X: x, Y: y},
+					Orientation: op.orientation,
+					Points:      make([]utils.Point[KT], 0, len(op.cells)),
+				}
+				ok := true
+				for _, c := range op.cells {
+					p := utils.Point[KT]{X: x + c.p.X, Y: y + c.p.Y}
+					if !b.compFunc(b.Get(p), c.v) {
+						ok = false
+						break
+					}
+					if opts.noOverlap {
+						if _, isUsed := used[p]; isUsed {
+							ok = false
+							break
+						}
+					}
+					match.Points = append(match.Points, p)
+				}
+				if !ok {
+					continue
+				}
+				if opts.noOverlap {
+					for _, p := range match.Points {
+						used[p] = struct{}{}
+					}
+				}
+				results = append(results, match)
+			}
+		}
+	}
+	return results
+}
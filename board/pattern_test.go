@@ -0,0 +1,107 @@
+package board
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// runeBoard returns a RuneBoard read from strings
+func runeBoard(lines ...string) *RuneBoard[int] {
+	b := NewRuneBoard[int]()
+	b.MustFromStrings(lines)
+	return b
+}
+
+// matchOffsets returns the X offsets of a list of matches
+func matchOffsets(matches []PatternMatch[int]) []int {
+	var xs []int
+	for _, m := range matches {
+		xs = append(xs, m.Offset.X)
+	}
+	return xs
+}
+
+func TestFindPatternWildcard(t *testing.T) {
+	b := runeBoard("#.#.###")
+	pattern := runeBoard("#?#")
+	matches := b.FindPattern(&pattern.Board, WithWildcard[rune]('?'))
+	if got := matchOffsets(matches); !slices.Equal(got, []int{0, 2, 4}) {
+		t.Errorf("expected matches at 0, 2 and 4, got %v", got)
+	}
+	if len(matches) > 0 && !slices.Equal(matches[0].Points, []utils.Point[int]{{X: 0, Y: 0}, {X: 2, Y: 0}}) {
+		t.Errorf("expected the wildcard cell to be left out of the points, got %v", matches[0].Points)
+	}
+	if got := matchOffsets(b.FindPattern(&pattern.Board)); got != nil {
+		t.Errorf("expected no matches without the wildcard option, got %v", got)
+	}
+	// empty cells in the pattern must match empty cells on the board
+	if got := matchOffsets(b.FindPattern(&runeBoard("#.").Board)); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("expected empty pattern cells to match only empty board cells, got %v", got)
+	}
+}
+
+func TestFindPatternWithoutOverlap(t *testing.T) {
+	b := runeBoard("#.#.###")
+	pattern := runeBoard("#?#")
+	matches := b.FindPattern(&pattern.Board, WithWildcard[rune]('?'), WithoutOverlap[rune]())
+	if got := matchOffsets(matches); !slices.Equal(got, []int{0, 4}) {
+		t.Errorf("expected the match at 2 to be skipped as overlapping, got %v", got)
+	}
+	// wildcard cells may overlap
+	b = runeBoard("#.#.#")
+	matches = b.FindPattern(&runeBoard("#??").Board, WithWildcard[rune]('?'), WithoutOverlap[rune]())
+	if got := matchOffsets(matches); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("expected matches sharing only wildcard cells, got %v", got)
+	}
+}
+
+func TestFindPatternOrientations(t *testing.T) {
+	b := runeBoard(
+		".....",
+		".##..",
+		".#...",
+		"....#",
+		"...##",
+	)
+	pattern := runeBoard(
+		"#.",
+		"##",
+	)
+	matches := b.FindPattern(&pattern.Board)
+	if len(matches) != 0 {
+		t.Errorf("expected no matches in the pattern's own orientation, got %+v", matches)
+	}
+	matches = b.FindPattern(&pattern.Board, WithAllOrientations[rune]())
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+	if m := matches[0]; m.Offset != (utils.Point[int]{X: 1, Y: 1}) || m.Orientation != OrientRotate90 ||
+		!slices.Equal(m.Points, []utils.Point[int]{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}}) {
+		t.Errorf("unexpected first match %+v", m)
+	}
+	if m := matches[1]; m.Offset != (utils.Point[int]{X: 3, Y: 3}) || m.Orientation != OrientRotate270 {
+		t.Errorf("unexpected second match %+v", m)
+	}
+
+	// a symmetric pattern is only reported once per place, however many orientations produce it
+	b = runeBoard(
+		"#.#",
+		".#.",
+		"#.#",
+	)
+	matches = b.FindPattern(&runeBoard("#.", ".#").Board, WithAllOrientations[rune]())
+	if len(matches) != 4 {
+		t.Errorf("expected 4 matches, got %+v", matches)
+	}
+	for _, m := range matches {
+		want := OrientIdentity
+		if m.Offset.X != m.Offset.Y {
+			want = OrientRotate90
+		}
+		if m.Orientation != want {
+			t.Errorf("at %v: expected %v, got %v", m.Offset, want, m.Orientation)
+		}
+	}
+}
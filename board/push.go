@@ -0,0 +1,161 @@
+package board
+
+import (
+	"sort"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// Tilt slides every movable cell as far as it will go in a direction, stopping at blocking cells, other movable
+// cells that have already stopped, and the edge of the board's bounds.  Cells that are neither movable nor
+// blocking are empty space.  On a wrapping or tiled board, cells slide across the edges until they reach a
+// blocking cell, and cells in a row or column with no blocking cells never come to rest, so they are left where
+// they are.  The direction must be one of the four cardinal directions.  It returns true if anything moved.
+func (b *Board[KT, VT]) Tilt(dir utils.StdPoint, movable func(VT) bool, blocking func(VT) bool) bool {
+	if b.bounds == nil {
+		panic("bounds not defined")
+	}
+	var cells []utils.Point[KT]
+	for p, v := range b.All() {
+		if b.Contains(p) && movable(v) {
+			cells = append(cells, p)
+		}
+	}
+	// move the cells furthest in the direction of travel first, so they are out of the way of the others
+	lead := func(p utils.Point[KT]) int {
+		return int(p.X)*dir.X + int(p.Y)*dir.Y
+	}
+	if b.Wraps() {
+		// lines have no ends, so the cells nearest to the blocking cell ahead of them go first
+		span := int(b.Bounds().Height())
+		if dir.X != 0 {
+			span = int(b.Bounds().Width())
+		}
+		ahead := make(map[utils.Point[KT]]int)
+		var settling []utils.Point[KT]
+		for _, p := range cells {
+			for d := 1; d < span; d++ {
+				if blocking(b.Get(utils.Point[KT]{X: p.X + KT(d*dir.X), Y: p.Y + KT(d*dir.Y)})) {
+					ahead[p] = d
+					settling = append(settling, p)
+					break
+				}
+			}
+		}
+		cells = settling
+		lead = func(p utils.Point[KT]) int {
+			return -ahead[p]
+		}
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return lead(cells[i]) > lead(cells[j])
+	})
+	moved := false
+	for _, p := range cells {
+		v := b.Get(p)
+		dest := p
+		for {
+			np := utils.Point[KT]{X: dest.X + KT(dir.X), Y: dest.Y + KT(dir.Y)}
+			if !b.Contains(np) {
+				break
+			}
+			nv := b.Get(np)
+			if movable(nv) || blocking(nv) {
+				break
+			}
+			dest = np
+		}
+		if dest != p {
+			b.Clear(p)
+			b.Set(dest, v)
+			moved = true
+		}
+	}
+	return moved
+}
+
+// PushRules describes how cells behave when pushed
+type PushRules[KT constraints.Integer, VT any] struct {
+	// Movable returns true for values that are pushed along
+	Movable func(VT) bool
+	// Blocking returns true for values that stop a push
+	Blocking func(VT) bool
+	// Linked returns the other points that always move with a point, such as the other half of a wide object.
+	// It may be nil.
+	Linked func(p utils.Point[KT], v VT) []utils.Point[KT]
+}
+
+// Push moves the cell at a point one step in a direction, along with every movable cell in its way and every cell
+// linked to one that moves.  Nothing moves if any of them would run into a blocking cell or leave the board's
+// bounds.  It returns true if the push happened.
+func (b *Board[KT, VT]) Push(from utils.Point[KT], dir utils.StdPoint, rules PushRules[KT, VT]) bool {
+	group := map[utils.Point[KT]]struct{}{from: {}}
+	queue := []utils.Point[KT]{from}
+	add := func(p utils.Point[KT]) {
+		if _, ok := group[p]; !ok {
+			group[p] = struct{}{}
+			queue = append(queue, p)
+		}
+	}
+	for head := 0; head < len(queue); head++ {
+		p := queue[head]
+		if rules.Linked != nil {
+			for _, lp := range rules.Linked(p, b.Get(p)) {
+				add(lp)
+			}
+		}
+		np := utils.Point[KT]{X: p.X + KT(dir.X), Y: p.Y + KT(dir.Y)}
+		if !b.Contains(np) {
+			return false
+		}
+		nv := b.Get(np)
+		if rules.Blocking(nv) {
+			return false
+		}
+		if rules.Movable(nv) {
+			add(np)
+		}
+	}
+	type move struct {
+		p utils.Point[KT]
+		v VT
+	}
+	moves := make([]move, 0, len(queue))
+	for _, p := range queue {
+		moves = append(moves, move{p: p, v: b.Get(p)})
+		b.Clear(p)
+	}
+	for _, m := range moves {
+		b.Set(utils.Point[KT]{X: m.p.X + KT(dir.X), Y: m.p.Y + KT(dir.Y)}, m.v)
+	}
+	return true
+}
+
+// PushRules returns the default push rules for a rune board: '#' blocks, '[' and ']' are the two halves of a
+// wide object, and every other non-empty cell is movable
+func (b *RuneBoard[KT]) PushRules() PushRules[KT, rune] {
+	return PushRules[KT, rune]{
+		Movable: func(r rune) bool {
+			return r != b.emptyVal && r != '#'
+		},
+		Blocking: func(r rune) bool {
+			return r == '#'
+		},
+		Linked: func(p utils.Point[KT], r rune) []utils.Point[KT] {
+			switch r {
+			case '[':
+				return []utils.Point[KT]{{X: p.X + 1, Y: p.Y}}
+			case ']':
+				return []utils.Point[KT]{{X: p.X - 1, Y: p.Y}}
+			}
+			return nil
+		},
+	}
+}
+
+// Push moves the cell at a point one step in a direction using the default rune board push rules, and returns
+// true if the push happened
+func (b *RuneBoard[KT]) Push(from utils.Point[KT], dir utils.StdPoint) bool {
+	return b.Board.Push(from, dir, b.PushRules())
+}
@@ -0,0 +1,196 @@
+package board
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+var (
+	north = utils.StdPoint{X: 0, Y: -1}
+	south = utils.StdPoint{X: 0, Y: 1}
+	west  = utils.StdPoint{X: -1, Y: 0}
+	east  = utils.StdPoint{X: 1, Y: 0}
+)
+
+// isRock and isCube are the movable and blocking functions for the rocks of Advent of Code 2023 day 14
+func isRock(r rune) bool {
+	return r == 'O'
+}
+
+func isCube(r rune) bool {
+	return r == '#'
+}
+
+// platform is the example from Advent of Code 2023 day 14
+var platform = []string{
+	"O....#....",
+	"O.OO#....#",
+	".....##...",
+	"OO.#O....O",
+	".O.....O#.",
+	"O.#..O.#.#",
+	"..O..#O..O",
+	".......O..",
+	"#....###..",
+	"#OO..#....",
+}
+
+func TestTilt(t *testing.T) {
+	b := NewRuneBoard[int]()
+	b.MustFromStrings(platform)
+	if !b.Tilt(north, isRock, isCube) {
+		t.Errorf("expected the tilt to move rocks")
+	}
+	want := []string{
+		"OOOO.#.O..",
+		"OO..#....#",
+		"OO..O##..O",
+		"O..#.OO...",
+		"........#.",
+		"..#....#.#",
+		"..O..#.O.O",
+		"..O.......",
+		"#....###..",
+		"#....#....",
+	}
+	if got := b.Format(); !slices.Equal(got, want) {
+		t.Errorf("north: expected\n%q\ngot\n%q", want, got)
+	}
+	load := 0
+	for p, v := range b.All() {
+		if v == 'O' {
+			load += len(platform) - p.Y
+		}
+	}
+	if load != 136 {
+		t.Errorf("expected a load of 136, got %d", load)
+	}
+	if b.Tilt(north, isRock, isCube) {
+		t.Errorf("expected a second tilt the same way to move nothing")
+	}
+
+	// one spin cycle tilts north, west, south and east
+	b.MustFromStrings(platform)
+	for _, dir := range []utils.StdPoint{north, west, south, east} {
+		b.Tilt(dir, isRock, isCube)
+	}
+	want = []string{
+		".....#....",
+		"....#...O#",
+		"...OO##...",
+		".OO#......",
+		".....OOO#.",
+		".O#...O#.#",
+		"....O#....",
+		"......OOOO",
+		"#...O###..",
+		"#..OO#....",
+	}
+	if got := b.Format(); !slices.Equal(got, want) {
+		t.Errorf("spin cycle: expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestTiltWrapping(t *testing.T) {
+	b := NewRuneBoard[int](WithWrap[int, rune]())
+	b.MustFromStrings([]string{
+		"O.#.O",
+		".O...",
+		"..#..",
+	})
+	if !b.Tilt(east, isRock, isCube) {
+		t.Errorf("expected the tilt to move rocks")
+	}
+	// the rock at the east edge wraps around behind the one that started at the west edge, and the rock in a
+	// row with no cubes is left alone
+	want := []string{
+		"OO#..",
+		".O...",
+		"..#..",
+	}
+	if got := b.Format(); !slices.Equal(got, want) {
+		t.Errorf("east: expected %q, got %q", want, got)
+	}
+	if b.Tilt(east, isRock, isCube) {
+		t.Errorf("expected a second tilt the same way to move nothing")
+	}
+
+	b.MustFromStrings([]string{"O", ".", "#", "."})
+	b.Tilt(north, isRock, isCube)
+	if got := b.Format(); !slices.Equal(got, []string{".", ".", "#", "O"}) {
+		t.Errorf("north: expected the rock to wrap to the bottom, got %q", got)
+	}
+}
+
+// warehouse returns the board for the push tests, with the robot below a stack of wide boxes
+func warehouse(t *testing.T) *RuneBoard[int] {
+	t.Helper()
+	b := NewRuneBoard[int]()
+	err := b.FromStrings([]string{
+		"#######",
+		"#.....#",
+		"#.[][]#",
+		"#..[].#",
+		"#..@..#",
+		"#######",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestPushWide(t *testing.T) {
+	b := warehouse(t)
+	robot := utils.Point[int]{X: 3, Y: 4}
+	if !b.Push(robot, north) {
+		t.Fatalf("expected the push to succeed")
+	}
+	// the box above the robot pushes both boxes above it, each half of each box moving together
+	want := []string{
+		"#######",
+		"#.[][]#",
+		"#..[].#",
+		"#..@..#",
+		"#.....#",
+		"#######",
+	}
+	if got := b.Format(); !slices.Equal(got, want) {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+	robot.Y--
+	if b.Push(robot, north) {
+		t.Errorf("expected a push into the wall to fail")
+	}
+	if got := b.Format(); !slices.Equal(got, want) {
+		t.Errorf("expected a blocked push to leave the board unchanged, got\n%q", got)
+	}
+}
+
+func TestPushBlockedBranch(t *testing.T) {
+	// only one of the two boxes the push fans out to is blocked, so nothing moves
+	b := warehouse(t)
+	b.Set(utils.Point[int]{X: 5, Y: 1}, '#')
+	before := b.Format()
+	if b.Push(utils.Point[int]{X: 3, Y: 4}, north) {
+		t.Errorf("expected the push to fail")
+	}
+	if got := b.Format(); !slices.Equal(got, before) {
+		t.Errorf("expected the board to be unchanged, got\n%q", got)
+	}
+	// sideways, a wide box moves one cell along with the robot
+	b = warehouse(t)
+	b.Set(utils.Point[int]{X: 3, Y: 4}, '.')
+	b.Set(utils.Point[int]{X: 1, Y: 3}, '@')
+	if !b.Push(utils.Point[int]{X: 1, Y: 3}, east) || !b.Push(utils.Point[int]{X: 2, Y: 3}, east) {
+		t.Fatalf("expected the pushes to succeed")
+	}
+	if got := b.Format()[3]; got != "#..@[]#" {
+		t.Errorf("expected the box to move east, got %q", got)
+	}
+	if b.Push(utils.Point[int]{X: 3, Y: 3}, east) {
+		t.Errorf("expected a push of the box into the wall to fail")
+	}
+}
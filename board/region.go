@@ -0,0 +1,177 @@
+package board
+
+import (
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// Region is a connected group of same-valued points, with its geometry
+type Region[KT constraints.Integer, VT any] struct {
+	// Value is the value shared by the region's points
+	Value VT
+	// Points are the points in the region
+	Points map[utils.Point[KT]]struct{}
+	// Area is the number of points in the region
+	Area int
+	// Perimeter is the number of cell edges between the region and the outside, including around holes
+	Perimeter int
+	// Sides is the number of straight sides of the region, including around holes
+	Sides int
+	// Holes is the number of areas not in the region that are completely enclosed by it
+	Holes int
+	// Bounds is the smallest rectangle containing the region
+	Bounds utils.Rectangle[KT]
+	// Outline is the outer boundary of the region, as the corners of a polygon listed clockwise starting from
+	// the top left.  A cell at (x, y) has corners from (x, y) to (x+1, y+1).
+	Outline []utils.Point[KT]
+}
+
+// NewRegion computes the geometry of a group of points with a given value
+func NewRegion[KT constraints.Integer, VT any](value VT, points map[utils.Point[KT]]struct{}) *Region[KT, VT] {
+	r := &Region[KT, VT]{
+		Value:  value,
+		Points: points,
+		Area:   len(points),
+	}
+	if len(points) == 0 {
+		return r
+	}
+	in := func(x, y KT) bool {
+		_, ok := points[utils.Point[KT]{X: x, Y: y}]
+		return ok
+	}
+	first := true
+	for p := range points {
+		if first {
+			r.Bounds = utils.Rectangle[KT]{P1: p, P2: p}
+			first = false
+		}
+		r.Bounds.P1.X, r.Bounds.P2.X = min(r.Bounds.P1.X, p.X), max(r.Bounds.P2.X, p.X)
+		r.Bounds.P1.Y, r.Bounds.P2.Y = min(r.Bounds.P1.Y, p.Y), max(r.Bounds.P2.Y, p.Y)
+		for _, d := range []utils.StdPoint{{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1}} {
+			if !in(p.X+KT(d.X), p.Y+KT(d.Y)) {
+				r.Perimeter++
+			}
+		}
+		// every corner of the region is the end of one side
+		for _, d := range []utils.StdPoint{{X: -1, Y: -1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: 1}} {
+			h := in(p.X+KT(d.X), p.Y)
+			v := in(p.X, p.Y+KT(d.Y))
+			if (!h && !v) || (h && v && !in(p.X+KT(d.X), p.Y+KT(d.Y))) {
+				r.Sides++
+			}
+		}
+	}
+	r.Holes = r.countHoles()
+	r.Outline = r.traceOutline()
+	return r
+}
+
+// countHoles counts the 8-connected areas outside the region that do not reach beyond its bounds
+func (r *Region[KT, VT]) countHoles() int {
+	minX, minY := int(r.Bounds.P1.X)-1, int(r.Bounds.P1.Y)-1
+	maxX, maxY := int(r.Bounds.P2.X)+1, int(r.Bounds.P2.Y)+1
+	type ip struct{ x, y int }
+	visited := make(map[ip]struct{})
+	outside := func(c ip) bool {
+		if c.x < minX || c.y < minY || c.x > maxX || c.y > maxY {
+			return false
+		}
+		_, ok := r.Points[utils.Point[KT]{X: KT(c.x), Y: KT(c.y)}]
+		return !ok
+	}
+	holes := 0
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			start := ip{x: x, y: y}
+			if _, ok := visited[start]; ok || !outside(start) {
+				continue
+			}
+			edge := false
+			queue := []ip{start}
+			visited[start] = struct{}{}
+			for head := 0; head < len(queue); head++ {
+				c := queue[head]
+				if c.x == minX || c.y == minY || c.x == maxX || c.y == maxY {
+					edge = true
+				}
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						n := ip{x: c.x + dx, y: c.y + dy}
+						if _, ok := visited[n]; ok || !outside(n) {
+							continue
+						}
+						visited[n] = struct{}{}
+						queue = append(queue, n)
+					}
+				}
+			}
+			if !edge {
+				holes++
+			}
+		}
+	}
+	return holes
+}
+
+// traceOutline follows the outer boundary clockwise, keeping the region on the right, from the top left corner of
+// the region's top left cell.  At each corner it turns right if it can, so cells that only touch diagonally are
+// kept apart.
+func (r *Region[KT, VT]) traceOutline() []utils.Point[KT] {
+	in := func(v utils.Point[KT], off utils.StdPoint) bool {
+		_, ok := r.Points[utils.Point[KT]{X: v.X + KT(off.X), Y: v.Y + KT(off.Y)}]
+		return ok
+	}
+	var start utils.Point[KT]
+	found := false
+	for y := r.Bounds.P1.Y; y <= r.Bounds.P2.Y && !found; y++ {
+		for x := r.Bounds.P1.X; x <= r.Bounds.P2.X; x++ {
+			if _, ok := r.Points[utils.Point[KT]{X: x, Y: y}]; ok {
+				start = utils.Point[KT]{X: x, Y: y}
+				found = true
+				break
+			}
+		}
+	}
+	// directions in clockwise order, with the cells ahead-left and ahead-right of a corner when heading that way
+	dirs := []struct {
+		d     utils.StdPoint
+		left  utils.StdPoint
+		right utils.StdPoint
+	}{
+		{d: utils.StdPoint{X: 1, Y: 0}, left: utils.StdPoint{X: 0, Y: -1}, right: utils.StdPoint{X: 0, Y: 0}},
+		{d: utils.StdPoint{X: 0, Y: 1}, left: utils.StdPoint{X: 0, Y: 0}, right: utils.StdPoint{X: -1, Y: 0}},
+		{d: utils.StdPoint{X: -1, Y: 0}, left: utils.StdPoint{X: -1, Y: 0}, right: utils.StdPoint{X: -1, Y: -1}},
+		{d: utils.StdPoint{X: 0, Y: -1}, left: utils.StdPoint{X: -1, Y: -1}, right: utils.StdPoint{X: 0, Y: -1}},
+	}
+	outline := []utils.Point[KT]{start}
+	v := start
+	dir := 0
+	for {
+		v = utils.Point[KT]{X: v.X + KT(dirs[dir].d.X), Y: v.Y + KT(dirs[dir].d.Y)}
+		next := dir
+		if !in(v, dirs[dir].right) {
+			next = (dir + 1) % 4
+		} else if in(v, dirs[dir].left) {
+			next = (dir + 3) % 4
+		}
+		if v == start && next == 0 {
+			break
+		}
+		if next != dir {
+			outline = append(outline, v)
+			dir = next
+		}
+	}
+	return outline
+}
+
+// FindRegionsDetailed groups a board into same-valued regions based on cardinal neighbors, as with FindRegions,
+// and computes the geometry of each region.
+func (b *Board[KT, VT]) FindRegionsDetailed(includeEmptyVal bool) []*Region[KT, VT] {
+	var results []*Region[KT, VT]
+	for _, reg := range b.FindRegions(includeEmptyVal) {
+		results = append(results, NewRegion(b.Get(utils.MustGetArbitraryKey(reg)), reg))
+	}
+	return results
+}
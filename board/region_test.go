@@ -0,0 +1,171 @@
+package board
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+// regionAt returns the region of a board containing a point
+func regionAt(b *RuneBoard[int], p utils.Point[int]) *Region[int, rune] {
+	for _, r := range b.FindRegionsDetailed(false) {
+		if _, ok := r.Points[p]; ok {
+			return r
+		}
+	}
+	return nil
+}
+
+func TestRegion(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []string
+		at        utils.Point[int]
+		area      int
+		perimeter int
+		sides     int
+		holes     int
+		outline   []utils.Point[int]
+	}{
+		{
+			name:      "single cell",
+			lines:     []string{"#"},
+			area:      1,
+			perimeter: 4,
+			sides:     4,
+			outline:   []utils.Point[int]{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+		},
+		{
+			name:      "L",
+			lines:     []string{"#.", "##"},
+			area:      3,
+			perimeter: 8,
+			sides:     6,
+			outline: []utils.Point[int]{
+				{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 2},
+			},
+		},
+		{
+			name:      "ring",
+			lines:     []string{"###", "#.#", "###"},
+			area:      8,
+			perimeter: 16,
+			sides:     8,
+			holes:     1,
+			outline:   []utils.Point[int]{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 3}, {X: 0, Y: 3}},
+		},
+		{
+			// the gap in the middle touches the outside at a corner, so it is part of the outer boundary, and
+			// the outline goes around it
+			name:      "hole touching a corner",
+			lines:     []string{"###", "#.#", "##."},
+			area:      7,
+			perimeter: 16,
+			sides:     10,
+			holes:     0,
+			outline: []utils.Point[int]{
+				{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2},
+				{X: 2, Y: 2}, {X: 2, Y: 3}, {X: 0, Y: 3},
+			},
+		},
+		{
+			// the two B blocks touch diagonally, so they make a single hole, and the A cells between them meet at
+			// a corner that counts as the end of two sides
+			name: "holes touching diagonally",
+			lines: []string{
+				"AAAAAA",
+				"AAABBA",
+				"AAABBA",
+				"ABBAAA",
+				"ABBAAA",
+				"AAAAAA",
+			},
+			area:      28,
+			perimeter: 40,
+			sides:     12,
+			holes:     1,
+			outline:   []utils.Point[int]{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 6}, {X: 0, Y: 6}},
+		},
+		{
+			name: "E shape",
+			lines: []string{
+				"EEEEE",
+				"EXXXX",
+				"EEEEE",
+				"EXXXX",
+				"EEEEE",
+			},
+			area:      17,
+			perimeter: 36,
+			sides:     12,
+			outline: []utils.Point[int]{
+				{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 5, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 5, Y: 2}, {X: 5, Y: 3},
+				{X: 1, Y: 3}, {X: 1, Y: 4}, {X: 5, Y: 4}, {X: 5, Y: 5}, {X: 0, Y: 5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewRuneBoard[int]()
+			b.MustFromStrings(tt.lines)
+			r := regionAt(b, tt.at)
+			if r == nil {
+				t.Fatalf("no region at %v", tt.at)
+			}
+			if r.Area != tt.area || r.Perimeter != tt.perimeter || r.Sides != tt.sides || r.Holes != tt.holes {
+				t.Errorf("expected area %d, perimeter %d, sides %d and holes %d, got %d, %d, %d and %d", tt.area,
+					tt.perimeter, tt.sides, tt.holes, r.Area, r.Perimeter, r.Sides, r.Holes)
+			}
+			if !slices.Equal(r.Outline, tt.outline) {
+				t.Errorf("expected outline %v, got %v", tt.outline, r.Outline)
+			}
+		})
+	}
+}
+
+func TestRegionsGardenPlots(t *testing.T) {
+	// the examples from Advent of Code 2024 day 12, priced by area times perimeter and by area times sides
+	tests := []struct {
+		lines    []string
+		byPerim  int
+		bySides  int
+		nRegions int
+	}{
+		{[]string{"AAAA", "BBCD", "BBCC", "EEEC"}, 140, 80, 5},
+		{[]string{"OOOOO", "OXOXO", "OOOOO", "OXOXO", "OOOOO"}, 772, 436, 5},
+		{[]string{"EEEEE", "EXXXX", "EEEEE", "EXXXX", "EEEEE"}, 692, 236, 3},
+		{[]string{"AAAAAA", "AAABBA", "AAABBA", "ABBAAA", "ABBAAA", "AAAAAA"}, 1184, 368, 3},
+		{[]string{
+			"RRRRIICCFF",
+			"RRRRIICCCF",
+			"VVRRRCCFFF",
+			"VVRCCCJFFF",
+			"VVVVCJJCFE",
+			"VVIVCCJJEE",
+			"VVIIICJJEE",
+			"MIIIIIJJEE",
+			"MIIISIJEEE",
+			"MMMISSJEEE",
+		}, 1930, 1206, 11},
+	}
+	for _, tt := range tests {
+		b := NewRuneBoard[int]()
+		b.MustFromStrings(tt.lines)
+		regions := b.FindRegionsDetailed(false)
+		byPerim, bySides := 0, 0
+		for _, r := range regions {
+			byPerim += r.Area * r.Perimeter
+			bySides += r.Area * r.Sides
+		}
+		if len(regions) != tt.nRegions || byPerim != tt.byPerim || bySides != tt.bySides {
+			t.Errorf("%s: expected %d regions priced %d and %d, got %d regions priced %d and %d", tt.lines[0],
+				tt.nRegions, tt.byPerim, tt.bySides, len(regions), byPerim, bySides)
+		}
+	}
+	b := NewRuneBoard[int]()
+	b.MustFromStrings(tests[1].lines)
+	if o := regionAt(b, utils.Point[int]{}); o.Holes != 4 || o.Sides != 20 {
+		t.Errorf("expected the O region to have 4 holes and 20 sides, got %d and %d", o.Holes, o.Sides)
+	}
+}
@@ -0,0 +1,204 @@
+package board
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// CellStyle is the appearance of a cell on a terminal.  Nil colors use the terminal's default.
+type CellStyle struct {
+	FG      color.Color
+	BG      color.Color
+	Bold    bool
+	Reverse bool
+}
+
+// over returns the style with any colors or attributes set in another style applied on top of it
+func (s CellStyle) over(o CellStyle) CellStyle {
+	if o.FG != nil {
+		s.FG = o.FG
+	}
+	if o.BG != nil {
+		s.BG = o.BG
+	}
+	s.Bold = s.Bold || o.Bold
+	s.Reverse = s.Reverse || o.Reverse
+	return s
+}
+
+// sgr returns the ANSI escape sequence that selects the style, starting from the default style
+func (s CellStyle) sgr() string {
+	seq := "\x1b[0"
+	if s.Bold {
+		seq += ";1"
+	}
+	if s.Reverse {
+		seq += ";7"
+	}
+	if s.FG != nil {
+		c := color.RGBAModel.Convert(s.FG).(color.RGBA)
+		seq += fmt.Sprintf(";38;2;%d;%d;%d", c.R, c.G, c.B)
+	}
+	if s.BG != nil {
+		c := color.RGBAModel.Convert(s.BG).(color.RGBA)
+		seq += fmt.Sprintf(";48;2;%d;%d;%d", c.R, c.G, c.B)
+	}
+	return seq + "m"
+}
+
+// TerminalOptions collects options when initializing a TerminalRenderer
+type TerminalOptions[KT constraints.Integer, VT any] struct {
+	output         io.Writer
+	styleFunc      func(p utils.Point[KT], v VT) CellStyle
+	highlight      map[utils.Point[KT]]struct{}
+	highlightStyle CellStyle
+	viewport       *utils.Rectangle[KT]
+	interval       time.Duration
+}
+
+// WithTerminalOutput sets where the renderer writes to.  The default is os.Stdout.
+func WithTerminalOutput[KT constraints.Integer, VT any](w io.Writer) func(*TerminalOptions[KT, VT]) {
+	return func(options *TerminalOptions[KT, VT]) {
+		options.output = w
+	}
+}
+
+// WithCellStyle provides a function giving the colors of each cell
+func WithCellStyle[KT constraints.Integer, VT any](styleFunc func(p utils.Point[KT], v VT) CellStyle) func(*TerminalOptions[KT, VT]) {
+	return func(options *TerminalOptions[KT, VT]) {
+		options.styleFunc = styleFunc
+	}
+}
+
+// WithHighlight sets a group of points, such as a path, to be drawn in a highlight style.  The default highlight
+// style is reverse video.
+func WithHighlight[KT constraints.Integer, VT any](points map[utils.Point[KT]]struct{}) func(*TerminalOptions[KT, VT]) {
+	return func(options *TerminalOptions[KT, VT]) {
+		options.highlight = points
+	}
+}
+
+// WithHighlightStyle sets the style of highlighted points, which is applied over the cell's own style
+func WithHighlightStyle[KT constraints.Integer, VT any](style CellStyle) func(*TerminalOptions[KT, VT]) {
+	return func(options *TerminalOptions[KT, VT]) {
+		options.highlightStyle = style
+	}
+}
+
+// WithViewport only draws the part of the board within a rectangle, instead of the board's bounds
+func WithViewport[KT constraints.Integer, VT any](viewport utils.Rectangle[KT]) func(*TerminalOptions[KT, VT]) {
+	return func(options *TerminalOptions[KT, VT]) {
+		options.viewport = &viewport
+	}
+}
+
+// WithRedrawInterval sets the minimum time between frames drawn by Redraw
+func WithRedrawInterval[KT constraints.Integer, VT any](interval time.Duration) func(*TerminalOptions[KT, VT]) {
+	return func(options *TerminalOptions[KT, VT]) {
+		options.interval = interval
+	}
+}
+
+// TerminalRenderer draws boards on an ANSI terminal, with colors and highlighting.  Using Redraw, successive
+// frames are drawn over each other to animate a simulation.
+type TerminalRenderer[KT constraints.Integer, VT any] struct {
+	TerminalOptions[KT, VT]
+	runeFunc  func(VT) rune
+	lastLines int
+	lastDraw  time.Time
+}
+
+// NewTerminalRenderer allocates and initializes a new TerminalRenderer.  The user must supply a conversion
+// function giving the rune to draw for each value.
+func NewTerminalRenderer[KT constraints.Integer, VT any](runeFunc func(VT) rune, options ...func(*TerminalOptions[KT, VT])) *TerminalRenderer[KT, VT] {
+	t := &TerminalRenderer[KT, VT]{
+		TerminalOptions: TerminalOptions[KT, VT]{
+			output:         os.Stdout,
+			highlightStyle: CellStyle{Reverse: true},
+		},
+		runeFunc: runeFunc,
+	}
+	for _, opt := range options {
+		opt(&t.TerminalOptions)
+	}
+	return t
+}
+
+// SetHighlight replaces the set of highlighted points
+func (t *TerminalRenderer[KT, VT]) SetHighlight(points map[utils.Point[KT]]struct{}) {
+	t.highlight = points
+}
+
+// SetViewport replaces the viewport
+func (t *TerminalRenderer[KT, VT]) SetViewport(viewport utils.Rectangle[KT]) {
+	t.viewport = &viewport
+}
+
+// Render draws a board below whatever was previously written
+func (t *TerminalRenderer[KT, VT]) Render(b *Board[KT, VT]) error {
+	t.lastLines = 0
+	return t.draw(b)
+}
+
+// Redraw draws a board over the previous frame.  If the redraw interval has not passed since the previous frame,
+// nothing is drawn and false is returned.
+func (t *TerminalRenderer[KT, VT]) Redraw(b *Board[KT, VT]) (bool, error) {
+	if t.interval > 0 && !t.lastDraw.IsZero() && time.Since(t.lastDraw) < t.interval {
+		return false, nil
+	}
+	return true, t.draw(b)
+}
+
+// draw writes a frame, first moving the cursor up over the previous frame if there is one
+func (t *TerminalRenderer[KT, VT]) draw(b *Board[KT, VT]) error {
+	r := b.Bounds()
+	if t.viewport != nil {
+		r = orderRect(*t.viewport)
+	}
+	w := bufio.NewWriter(t.output)
+	if t.lastLines > 0 {
+		_, _ = fmt.Fprintf(w, "\x1b[%dA\r", t.lastLines)
+	}
+	lines := 0
+	if b.bounds != nil || t.viewport != nil {
+		for y := r.P1.Y; y <= r.P2.Y; y++ {
+			cur := ""
+			for x := r.P1.X; x <= r.P2.X; x++ {
+				p := utils.Point[KT]{X: x, Y: y}
+				v := b.Get(p)
+				var style CellStyle
+				if t.styleFunc != nil {
+					style = t.styleFunc(p, v)
+				}
+				if _, ok := t.highlight[p]; ok {
+					style = style.over(t.highlightStyle)
+				}
+				if seq := style.sgr(); seq != cur {
+					_, _ = w.WriteString(seq)
+					cur = seq
+				}
+				_, _ = w.WriteRune(t.runeFunc(v))
+			}
+			_, _ = w.WriteString("\x1b[0m\x1b[K\n")
+			lines++
+		}
+	}
+	if lines < t.lastLines {
+		// clear what is left of a larger previous frame
+		_, _ = w.WriteString("\x1b[J")
+	}
+	err := w.Flush()
+	if err != nil {
+		return err
+	}
+	t.lastLines = lines
+	t.lastDraw = time.Now()
+	return nil
+}
@@ -0,0 +1,122 @@
+package board
+
+import (
+	"bytes"
+	"flag"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// checkGolden compares output with a file in testdata, or rewrites the file if -update is given
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	fn := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		err := os.WriteFile(fn, got, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s:\ngot  %q\nwant %q", fn, got, want)
+	}
+}
+
+// terminalTestBoard returns a small board for rendering
+func terminalTestBoard(t *testing.T, lines ...string) *Board[int, rune] {
+	t.Helper()
+	b := NewRuneBoard[int]()
+	err := b.FromStrings(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &b.Board
+}
+
+// wallStyle draws walls in red on the default background, and open cells in bold
+func wallStyle(_ utils.Point[int], v rune) CellStyle {
+	if v == '#' {
+		return CellStyle{FG: color.RGBA{R: 0xff, A: 0xff}}
+	}
+	return CellStyle{Bold: true}
+}
+
+func identityRune(r rune) rune {
+	return r
+}
+
+func TestTerminalStyles(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTerminalRenderer(identityRune,
+		WithTerminalOutput[int, rune](&buf),
+		WithCellStyle(wallStyle),
+		WithHighlight[int, rune](map[utils.Point[int]]struct{}{
+			{X: 1, Y: 1}: {},
+			{X: 2, Y: 1}: {},
+		}),
+	)
+	err := tr.Render(terminalTestBoard(t, "#..#", "#..#", "####"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr = NewTerminalRenderer(identityRune,
+		WithTerminalOutput[int, rune](&buf),
+		WithCellStyle(wallStyle),
+		WithHighlightStyle[int, rune](CellStyle{BG: color.RGBA{B: 0x80, A: 0xff}, Bold: true}),
+	)
+	tr.SetHighlight(map[utils.Point[int]]struct{}{{X: 0, Y: 2}: {}})
+	err = tr.Render(terminalTestBoard(t, "#..#", "#..#", "####"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "terminal_styles", buf.Bytes())
+}
+
+func TestTerminalViewport(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTerminalRenderer(identityRune,
+		WithTerminalOutput[int, rune](&buf),
+		WithViewport[int, rune](utils.Rectangle[int]{P1: utils.Point[int]{X: 3, Y: 2}, P2: utils.Point[int]{X: 1, Y: 1}}),
+	)
+	err := tr.Render(terminalTestBoard(t, "abcde", "fghij", "klmno", "pqrst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "terminal_viewport", buf.Bytes())
+}
+
+func TestTerminalRedrawShrinks(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTerminalRenderer(identityRune,
+		WithTerminalOutput[int, rune](&buf),
+		WithCellStyle(wallStyle),
+	)
+	frames := [][]string{
+		{"#.#", "...", "#.#"},
+		{"...", ".#.", "..."},
+		{"##"},
+	}
+	for _, f := range frames {
+		drawn, err := tr.Redraw(terminalTestBoard(t, f...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !drawn {
+			t.Fatalf("expected every frame to be drawn")
+		}
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\x1b[J")) {
+		t.Errorf("expected the shrinking frame to clear the rest of the screen")
+	}
+	checkGolden(t, "terminal_redraw", buf.Bytes())
+}
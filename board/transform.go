@@ -0,0 +1,224 @@
+package board
+
+import (
+	"fmt"
+
+	"github.com/ghjm/advent_utils"
+	"golang.org/x/exp/constraints"
+)
+
+// Orientation is one of the eight symmetries of a rectangle (the dihedral group D4)
+type Orientation int
+
+const (
+	OrientIdentity Orientation = iota
+	OrientRotate90
+	OrientRotate180
+	OrientRotate270
+	OrientFlipH
+	OrientFlipHRotate90
+	OrientFlipHRotate180
+	OrientFlipHRotate270
+)
+
+// AllOrientations lists all eight orientations, starting with the identity
+var AllOrientations = []Orientation{
+	OrientIdentity, OrientRotate90, OrientRotate180, OrientRotate270,
+	OrientFlipH, OrientFlipHRotate90, OrientFlipHRotate180, OrientFlipHRotate270,
+}
+
+// String returns a description of the orientation
+func (o Orientation) String() string {
+	switch o {
+	case OrientIdentity:
+		return "identity"
+	case OrientRotate90:
+		return "rotate 90"
+	case OrientRotate180:
+		return "rotate 180"
+	case OrientRotate270:
+		return "rotate 270"
+	case OrientFlipH:
+		return "flip"
+	case OrientFlipHRotate90:
+		return "flip, rotate 90"
+	case OrientFlipHRotate180:
+		return "flip, rotate 180"
+	case OrientFlipHRotate270:
+		return "flip, rotate 270"
+	}
+	return fmt.Sprintf("Orientation(%d)", int(o))
+}
+
+// Apply maps a point relative to the top left of a w by h rectangle to its position in the transformed rectangle
+func (o Orientation) Apply(x, y, w, h int) (int, int) {
+	if o >= OrientFlipH {
+		x = w - 1 - x
+	}
+	switch o % 4 {
+	case 1:
+		return h - 1 - y, x
+	case 2:
+		return w - 1 - x, h - 1 - y
+	case 3:
+		return y, w - 1 - x
+	}
+	return x, y
+}
+
+// Swaps returns true if the orientation exchanges width and height
+func (o Orientation) Swaps() bool {
+	return o%2 == 1
+}
+
+// orderRect returns a rectangle with P1 at the top left and P2 at the bottom right
+func orderRect[KT constraints.Integer](r utils.Rectangle[KT]) utils.Rectangle[KT] {
+	return utils.Rectangle[KT]{
+		P1: utils.Point[KT]{X: min(r.P1.X, r.P2.X), Y: min(r.P1.Y, r.P2.Y)},
+		P2: utils.Point[KT]{X: max(r.P1.X, r.P2.X), Y: max(r.P1.Y, r.P2.Y)},
+	}
+}
+
+// newLike returns an empty board with the same options and storage type as this one, and the given bounds
+func (b *Board[KT, VT]) newLike(bounds utils.Rectangle[KT]) *Board[KT, VT] {
+	nb := &Board[KT, VT]{BoardOptions: b.BoardOptions}
+	storage := b.storage
+	for {
+		cow, ok := storage.(*CopyOnWriteStorage[KT, VT])
+		if !ok {
+			break
+		}
+		storage = cow.underlying
+	}
+	nb.storage = storage.CopyToBoardStorage()
+	bounds = orderRect(bounds)
+	nb.storage.Allocate(max(bounds.P2.X+1, bounds.Width()), max(bounds.P2.Y+1, bounds.Height()), b.emptyVal)
+	nb.bounds = &bounds
+	return nb
+}
+
+// Orient returns a new board transformed to the given orientation.  The top left corner of the bounds stays in
+// place.
+func (b *Board[KT, VT]) Orient(o Orientation) *Board[KT, VT] {
+	r := b.Bounds()
+	w, h := int(r.Width()), int(r.Height())
+	nr := r
+	if o.Swaps() {
+		nr.P2 = utils.Point[KT]{X: r.P1.X + KT(h) - 1, Y: r.P1.Y + KT(w) - 1}
+	}
+	nb := b.newLike(nr)
+	b.storage.Iterate(func(p utils.Point[KT], v VT) bool {
+		x, y := o.Apply(int(p.X)-int(r.P1.X), int(p.Y)-int(r.P1.Y), w, h)
+		nb.storage.Set(utils.Point[KT]{X: r.P1.X + KT(x), Y: r.P1.Y + KT(y)}, v)
+		return true
+	})
+	return nb
+}
+
+// Orientations returns all eight orientations of the board, in the order of AllOrientations
+func (b *Board[KT, VT]) Orientations() []*Board[KT, VT] {
+	results := make([]*Board[KT, VT], 0, len(AllOrientations))
+	for _, o := range AllOrientations {
+		results = append(results, b.Orient(o))
+	}
+	return results
+}
+
+// Rotate90 returns a new board rotated 90 degrees clockwise
+func (b *Board[KT, VT]) Rotate90() *Board[KT, VT] {
+	return b.Orient(OrientRotate90)
+}
+
+// Rotate180 returns a new board rotated 180 degrees
+func (b *Board[KT, VT]) Rotate180() *Board[KT, VT] {
+	return b.Orient(OrientRotate180)
+}
+
+// Rotate270 returns a new board rotated 270 degrees clockwise (90 degrees counterclockwise)
+func (b *Board[KT, VT]) Rotate270() *Board[KT, VT] {
+	return b.Orient(OrientRotate270)
+}
+
+// FlipH returns a new board mirrored left to right
+func (b *Board[KT, VT]) FlipH() *Board[KT, VT] {
+	return b.Orient(OrientFlipH)
+}
+
+// FlipV returns a new board mirrored top to bottom
+func (b *Board[KT, VT]) FlipV() *Board[KT, VT] {
+	return b.Orient(OrientFlipHRotate180)
+}
+
+// Transpose returns a new board mirrored across its main diagonal, so that rows become columns
+func (b *Board[KT, VT]) Transpose() *Board[KT, VT] {
+	return b.Orient(OrientFlipHRotate270)
+}
+
+// SubBoard returns a new board containing the part of this board within a rectangle, shifted so that the
+// rectangle's top left corner is at (0, 0)
+func (b *Board[KT, VT]) SubBoard(rect utils.Rectangle[KT]) *Board[KT, VT] {
+	rect = orderRect(rect)
+	nb := b.newLike(utils.Rectangle[KT]{
+		P2: utils.Point[KT]{X: rect.Width() - 1, Y: rect.Height() - 1},
+	})
+	b.storage.Iterate(func(p utils.Point[KT], v VT) bool {
+		if p.Within(rect) {
+			nb.storage.Set(utils.Point[KT]{X: p.X - rect.P1.X, Y: p.Y - rect.P1.Y}, v)
+		}
+		return true
+	})
+	return nb
+}
+
+// Paste returns a new board with the area within another board's bounds copied over this one, with the other
+// board's top left corner placed at a given point.  Empty cells of the source clear the cells beneath them.  The
+// bounds of the new board are the union of this board's bounds and the pasted area.
+func (b *Board[KT, VT]) Paste(src *Board[KT, VT], at utils.Point[KT]) *Board[KT, VT] {
+	sr := src.Bounds()
+	dr := utils.Rectangle[KT]{
+		P1: at,
+		P2: utils.Point[KT]{X: at.X + sr.Width() - 1, Y: at.Y + sr.Height() - 1},
+	}
+	bounds := dr
+	if b.bounds != nil {
+		r := b.Bounds()
+		bounds.P1 = utils.Point[KT]{X: min(r.P1.X, dr.P1.X), Y: min(r.P1.Y, dr.P1.Y)}
+		bounds.P2 = utils.Point[KT]{X: max(r.P2.X, dr.P2.X), Y: max(r.P2.Y, dr.P2.Y)}
+	}
+	nb := b.newLike(bounds)
+	b.storage.Iterate(func(p utils.Point[KT], v VT) bool {
+		if !p.Within(dr) {
+			nb.storage.Set(p, v)
+		}
+		return true
+	})
+	src.storage.Iterate(func(p utils.Point[KT], v VT) bool {
+		if p.Within(sr) {
+			nb.storage.Set(utils.Point[KT]{X: at.X + p.X - sr.P1.X, Y: at.Y + p.Y - sr.P1.Y}, v)
+		}
+		return true
+	})
+	return nb
+}
+
+// Tile returns a new board made of nx by ny copies of the area within this board's bounds
+func (b *Board[KT, VT]) Tile(nx, ny int) *Board[KT, VT] {
+	r := b.Bounds()
+	w, h := r.Width(), r.Height()
+	nb := b.newLike(utils.Rectangle[KT]{
+		P1: r.P1,
+		P2: utils.Point[KT]{X: r.P1.X + w*KT(nx) - 1, Y: r.P1.Y + h*KT(ny) - 1},
+	})
+	b.storage.Iterate(func(p utils.Point[KT], v VT) bool {
+		if !p.Within(r) {
+			return true
+		}
+		for ty := 0; ty < ny; ty++ {
+			for tx := 0; tx < nx; tx++ {
+				nb.storage.Set(utils.Point[KT]{X: p.X + w*KT(tx), Y: p.Y + h*KT(ty)}, v)
+			}
+		}
+		return true
+	})
+	return nb
+}
@@ -0,0 +1,67 @@
+package board
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ghjm/advent_utils"
+)
+
+func TestOrient(t *testing.T) {
+	want := map[Orientation][]string{
+		OrientIdentity:       {"abc", "def"},
+		OrientRotate90:       {"da", "eb", "fc"},
+		OrientRotate180:      {"fed", "cba"},
+		OrientRotate270:      {"cf", "be", "ad"},
+		OrientFlipH:          {"cba", "fed"},
+		OrientFlipHRotate90:  {"fc", "eb", "da"},
+		OrientFlipHRotate180: {"def", "abc"},
+		OrientFlipHRotate270: {"ad", "be", "cf"},
+	}
+	// the same board at the origin and with its top left corner at (10, -5)
+	atOrigin := NewRuneBoard[int]()
+	atOrigin.MustFromStrings([]string{"abc", "def"})
+	offset := NewRuneBoard[int]()
+	for p, v := range atOrigin.All() {
+		offset.SetAndExpandBounds(utils.Point[int]{X: p.X + 10, Y: p.Y - 5}, v)
+	}
+	for _, b := range []*RuneBoard[int]{atOrigin, offset} {
+		p1 := b.Bounds().P1
+		for i, nb := range b.Orientations() {
+			o := AllOrientations[i]
+			if got := nb.Format(identityRune); !slices.Equal(got, want[o]) {
+				t.Errorf("%v at %v: expected %q, got %q", o, p1, want[o], got)
+			}
+			if nb.Bounds().P1 != p1 {
+				t.Errorf("%v at %v: expected the top left corner to stay in place, got %v", o, p1, nb.Bounds().P1)
+			}
+			if w := nb.Bounds().Width(); (w == 2) != o.Swaps() {
+				t.Errorf("%v: unexpected width %d", o, w)
+			}
+		}
+	}
+	named := map[string]*Board[int, rune]{
+		"Rotate90":  atOrigin.Rotate90(),
+		"Rotate180": atOrigin.Rotate180(),
+		"Rotate270": atOrigin.Rotate270(),
+		"FlipH":     atOrigin.FlipH(),
+		"FlipV":     atOrigin.FlipV(),
+		"Transpose": atOrigin.Transpose(),
+	}
+	namedWant := map[string][]string{
+		"Rotate90":  want[OrientRotate90],
+		"Rotate180": want[OrientRotate180],
+		"Rotate270": want[OrientRotate270],
+		"FlipH":     want[OrientFlipH],
+		"FlipV":     {"def", "abc"},
+		"Transpose": {"ad", "be", "cf"},
+	}
+	for name, nb := range named {
+		if got := nb.Format(identityRune); !slices.Equal(got, namedWant[name]) {
+			t.Errorf("%s: expected %q, got %q", name, namedWant[name], got)
+		}
+	}
+	if got := atOrigin.Format(); !slices.Equal(got, want[OrientIdentity]) {
+		t.Errorf("expected the original board to be unchanged, got %q", got)
+	}
+}
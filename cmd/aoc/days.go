@@ -0,0 +1,4 @@
+package main
+
+// Day packages are imported here so that their solutions are registered.  "aoc new" adds to this list.
+import ()
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/ghjm/advent_utils/runner"
+)
+
+func main() {
+	os.Exit(runner.Main(os.Args[1:]))
+}
@@ -0,0 +1,68 @@
+package utils
+
+// Cycle describes a sequence of states that eventually repeats.  States Start through Start+Length-1 repeat
+// forever after the first Start states.
+type Cycle[S any] struct {
+	Start  int
+	Length int
+	states []S
+}
+
+// FindCycle repeatedly applies a step function to an initial state until a state repeats, as identified by a key
+// function.  The step function must return a new state rather than modifying its argument, because every state
+// up to the repeat is kept.  FindCycle does not return if the states never repeat.
+func FindCycle[S any, K comparable](state S, step func(S) S, key func(S) K) Cycle[S] {
+	return FindCycleFunc(state, step, key, nil, -1)
+}
+
+// FindCycleFunc is like FindCycle, but if equal is not nil, two states with the same key are only taken to be the
+// same if equal also returns true, so the key can be a hash that might collide.  If limit is not negative, at most
+// limit steps are taken; if no state has repeated by then, the returned Cycle has a Length of 0 and only knows the
+// states up to step limit.
+func FindCycleFunc[S any, K comparable](state S, step func(S) S, key func(S) K, equal func(S, S) bool, limit int) Cycle[S] {
+	seen := make(map[K][]int)
+	var states []S
+	for {
+		k := key(state)
+		for _, i := range seen[k] {
+			if equal == nil || equal(states[i], state) {
+				return Cycle[S]{
+					Start:  i,
+					Length: len(states) - i,
+					states: states,
+				}
+			}
+		}
+		seen[k] = append(seen[k], len(states))
+		states = append(states, state)
+		if limit >= 0 && len(states) > limit {
+			return Cycle[S]{
+				Start:  len(states),
+				states: states,
+			}
+		}
+		state = step(state)
+	}
+}
+
+// Found returns true if the states were found to repeat
+func (c Cycle[S]) Found() bool {
+	return c.Length > 0
+}
+
+// Index returns the index of the already-computed state that is the same as the state after n steps.  It panics
+// if no cycle was found and n is past the last computed state.
+func (c Cycle[S]) Index(n int) int {
+	if n < c.Start {
+		return n
+	}
+	if c.Length == 0 {
+		panic("no cycle found within the step limit")
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// At returns the state after n steps
+func (c Cycle[S]) At(n int) S {
+	return c.states[c.Index(n)]
+}
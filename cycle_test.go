@@ -0,0 +1,60 @@
+package utils
+
+import "testing"
+
+func TestFindCycle(t *testing.T) {
+	// 0, 1, 2, 3, 4, 5, 3, 4, 5, ...
+	step := func(s int) int {
+		if s == 5 {
+			return 3
+		}
+		return s + 1
+	}
+	c := FindCycle(0, step, func(s int) int { return s })
+	if c.Start != 3 || c.Length != 3 || !c.Found() {
+		t.Fatalf("expected a cycle of length 3 starting at 3, got %d/%d", c.Start, c.Length)
+	}
+	for n, want := range map[int]int{0: 0, 2: 2, 3: 3, 6: 3, 1000: 4} {
+		if got := c.At(n); got != want {
+			t.Errorf("At(%d): expected %d, got %d", n, want, got)
+		}
+	}
+}
+
+func TestFindCycleFuncCollisions(t *testing.T) {
+	// every state has the same key, so only equal can tell them apart
+	step := func(s int) int {
+		return (s + 1) % 4
+	}
+	c := FindCycleFunc(0, step, func(int) int { return 0 }, func(a, b int) bool { return a == b }, -1)
+	if c.Start != 0 || c.Length != 4 {
+		t.Fatalf("expected a cycle of length 4 starting at 0, got %d/%d", c.Start, c.Length)
+	}
+	if got := c.At(4*1000 + 3); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+}
+
+func TestFindCycleFuncLimit(t *testing.T) {
+	calls := 0
+	step := func(s int) int {
+		calls++
+		return s + 1
+	}
+	c := FindCycleFunc(0, step, func(s int) int { return s }, nil, 10)
+	if c.Found() {
+		t.Errorf("expected no cycle")
+	}
+	if calls != 10 {
+		t.Errorf("expected 10 steps, got %d", calls)
+	}
+	if got := c.At(10); got != 10 {
+		t.Errorf("expected 10, got %d", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic past the step limit")
+		}
+	}()
+	c.At(11)
+}
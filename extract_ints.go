@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// IntOptions collects options for the integer extraction functions
+type IntOptions struct {
+	unsigned      bool
+	dashSeparator bool
+}
+
+// WithUnsigned treats every '-' as punctuation, so all extracted integers are non-negative
+func WithUnsigned() func(*IntOptions) {
+	return func(options *IntOptions) {
+		options.unsigned = true
+	}
+}
+
+// WithDashSeparator treats a '-' that directly follows a digit as a separator rather than a sign, so that
+// ranges like "3-5" yield 3 and 5.  A '-' anywhere else is still a sign.
+func WithDashSeparator() func(*IntOptions) {
+	return func(options *IntOptions) {
+		options.dashSeparator = true
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// intSpans finds the start and end offsets of each integer in a string, including any sign
+func intSpans(s string, options ...func(*IntOptions)) [][2]int {
+	var opts IntOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	var spans [][2]int
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if !opts.unsigned && start > 0 && s[start-1] == '-' {
+			if !opts.dashSeparator || start < 2 || !isDigit(s[start-2]) {
+				start--
+			}
+		}
+		spans = append(spans, [2]int{start, i})
+	}
+	return spans
+}
+
+// intOverflowError reports an integer that is too large for the requested type
+func intOverflowError(s string, err error) error {
+	return fmt.Errorf("integer %s does not fit: %w (use BigInts for very large numbers)", s, err)
+}
+
+// ExtractInts returns every integer found in a string, ignoring any surrounding punctuation.  It is an error if an
+// integer does not fit in an int; use BigInts for very large numbers.
+func ExtractInts(s string, options ...func(*IntOptions)) ([]int, error) {
+	var results []int
+	for _, sp := range intSpans(s, options...) {
+		v, err := strconv.Atoi(s[sp[0]:sp[1]])
+		if err != nil {
+			return nil, intOverflowError(s[sp[0]:sp[1]], err)
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// Ints returns every integer found in a string, as with ExtractInts, and panics if an integer does not fit
+func Ints(s string, options ...func(*IntOptions)) []int {
+	results, err := ExtractInts(s, options...)
+	if err != nil {
+		panic(err)
+	}
+	return results
+}
+
+// ExtractInts64 returns every integer found in a string as an int64, ignoring any surrounding punctuation.  It is
+// an error if an integer does not fit in an int64; use BigInts for very large numbers.
+func ExtractInts64(s string, options ...func(*IntOptions)) ([]int64, error) {
+	var results []int64
+	for _, sp := range intSpans(s, options...) {
+		v, err := strconv.ParseInt(s[sp[0]:sp[1]], 10, 64)
+		if err != nil {
+			return nil, intOverflowError(s[sp[0]:sp[1]], err)
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// Ints64 returns every integer found in a string, as with ExtractInts64, and panics if an integer does not fit
+func Ints64(s string, options ...func(*IntOptions)) []int64 {
+	results, err := ExtractInts64(s, options...)
+	if err != nil {
+		panic(err)
+	}
+	return results
+}
+
+// ExtractUInts returns every unsigned integer found in a string, treating '-' as punctuation.  It is an error if an
+// integer does not fit in a uint64; use BigInts for very large numbers.
+func ExtractUInts(s string) ([]uint64, error) {
+	var results []uint64
+	for _, sp := range intSpans(s, WithUnsigned()) {
+		v, err := strconv.ParseUint(s[sp[0]:sp[1]], 10, 64)
+		if err != nil {
+			return nil, intOverflowError(s[sp[0]:sp[1]], err)
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// UInts returns every unsigned integer found in a string, as with ExtractUInts, and panics if an integer does not
+// fit
+func UInts(s string) []uint64 {
+	results, err := ExtractUInts(s)
+	if err != nil {
+		panic(err)
+	}
+	return results
+}
+
+// BigInts returns every integer found in a string as a big.Int, so that numbers of any size can be extracted.  It
+// takes the same options as Ints.  It is a separate function rather than an option to Ints because the result type
+// differs.
+func BigInts(s string, options ...func(*IntOptions)) []*big.Int {
+	var results []*big.Int
+	for _, sp := range intSpans(s, options...) {
+		v, _ := new(big.Int).SetString(s[sp[0]:sp[1]], 10)
+		results = append(results, v)
+	}
+	return results
+}
+
+// Digits returns every individual decimal digit found in a string
+func Digits(s string) []int {
+	var results []int
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			results = append(results, int(s[i]-'0'))
+		}
+	}
+	return results
+}
+
+// OpenAndReadInts reads a file and returns the integers found on each line
+func OpenAndReadInts(name string, options ...func(*IntOptions)) ([][]int, error) {
+	var results [][]int
+	lineNum := 0
+	err := OpenAndReadLines(name, func(s string) error {
+		lineNum++
+		ints, err := ExtractInts(s, options...)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		results = append(results, ints)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// IntGrid parses lines of whitespace-separated integers.  Unlike Ints, any field that is not an integer is an error.
+func IntGrid(lines []string) ([][]int, error) {
+	var results [][]int
+	for i, line := range lines {
+		var row []int
+		for _, f := range strings.Fields(line) {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			row = append(row, v)
+		}
+		results = append(results, row)
+	}
+	return results, nil
+}
+
+// DigitGrid parses lines consisting entirely of decimal digits into a grid of single-digit values
+func DigitGrid(lines []string) ([][]int, error) {
+	var results [][]int
+	for i, line := range lines {
+		row := make([]int, 0, len(line))
+		for j := 0; j < len(line); j++ {
+			if !isDigit(line[j]) {
+				return nil, fmt.Errorf("line %d, column %d: non-digit %q", i+1, j+1, line[j])
+			}
+			row = append(row, int(line[j]-'0'))
+		}
+		results = append(results, row)
+	}
+	return results, nil
+}
+
+// OpenAndReadIntGrid reads a file of whitespace-separated integers as a grid
+func OpenAndReadIntGrid(name string) ([][]int, error) {
+	var lines []string
+	err := OpenAndReadLines(name, func(s string) error {
+		lines = append(lines, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return IntGrid(lines)
+}
+
+// OpenAndReadDigitGrid reads a file of decimal digits as a grid of single-digit values
+func OpenAndReadDigitGrid(name string) ([][]int, error) {
+	var lines []string
+	err := OpenAndReadLines(name, func(s string) error {
+		lines = append(lines, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return DigitGrid(lines)
+}
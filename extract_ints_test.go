@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		s       string
+		options []func(*IntOptions)
+		want    []int
+	}{
+		{"x=-5, y=12", nil, []int{-5, 12}},
+		{"x=-5, y=12", []func(*IntOptions){WithUnsigned()}, []int{5, 12}},
+		{"3-5,-7", nil, []int{3, -5, -7}},
+		{"3-5,-7", []func(*IntOptions){WithDashSeparator()}, []int{3, 5, -7}},
+		{"no numbers", nil, nil},
+	}
+	for _, tt := range tests {
+		if got := Ints(tt.s, tt.options...); !slices.Equal(got, tt.want) {
+			t.Errorf("Ints(%q): expected %v, got %v", tt.s, tt.want, got)
+		}
+	}
+	if got := Digits("a1b23"); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Digits: unexpected %v", got)
+	}
+}
+
+func TestIntsOverflow(t *testing.T) {
+	s := "1 99999999999999999999999 3"
+	if _, err := ExtractInts(s); err == nil || !strings.Contains(err.Error(), "BigInts") {
+		t.Errorf("ExtractInts: expected an error pointing to BigInts, got %v", err)
+	}
+	if _, err := ExtractInts64(s); err == nil {
+		t.Errorf("ExtractInts64: expected an error")
+	}
+	if _, err := ExtractUInts(s); err == nil {
+		t.Errorf("ExtractUInts: expected an error")
+	}
+	for name, f := range map[string]func(){
+		"Ints":   func() { Ints(s) },
+		"Ints64": func() { Ints64(s) },
+		"UInts":  func() { UInts(s) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+	big := BigInts(s)
+	if len(big) != 3 || big[1].String() != "99999999999999999999999" {
+		t.Errorf("BigInts: unexpected %v", big)
+	}
+	if got, err := ExtractInts64("-9223372036854775808 9223372036854775807"); err != nil || len(got) != 2 {
+		t.Errorf("ExtractInts64: unexpected %v, %v", got, err)
+	}
+}
+
+func TestOpenAndReadIntsOverflow(t *testing.T) {
+	useTestInputs(t, map[string]string{"input.txt": "1 2\n3 99999999999999999999999\n"})
+	_, err := OpenAndReadInts("input.txt")
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("expected an error on line 2, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package graph
+
+// ContractCorridors removes every node that is connected to exactly two other nodes, such as the cells of a
+// corridor in a maze, replacing each path through it with a single edge whose cost is the sum of the two edges it
+// replaces.  Repeating this leaves only junctions, dead ends and nodes for which keep returns true, which may be
+// nil.  One-way paths stay one-way, and nodes that no path passes through, such as a source with two one-way
+// edges leaving it, are kept.
+//
+// Two different paths between the same pair of nodes, such as two corridors joining the same junctions, are
+// deliberately kept as parallel edges, so that both shortest and longest paths through the contracted graph are
+// still correct.  Parallel edges with the same cost are redundant for both, so only one of them is kept.
+func (g *Graph[T]) ContractCorridors(keep func(T) bool) {
+	g.checkInit()
+	addEdge := func(from T, e Edge[T]) {
+		for _, oe := range g.Nodes[from] {
+			if oe == e {
+				return
+			}
+		}
+		g.Nodes[from] = append(g.Nodes[from], e)
+	}
+	preds := make(map[T]map[T]struct{})
+	addPred := func(to, from T) {
+		p, ok := preds[to]
+		if !ok {
+			p = make(map[T]struct{})
+			preds[to] = p
+		}
+		p[from] = struct{}{}
+	}
+	var open []T
+	for n, edges := range g.Nodes {
+		open = append(open, n)
+		for _, e := range edges {
+			addPred(e.Dest, n)
+		}
+	}
+	for len(open) > 0 {
+		n := open[len(open)-1]
+		open = open[:len(open)-1]
+		edges, ok := g.Nodes[n]
+		if !ok || (keep != nil && keep(n)) {
+			continue
+		}
+		neighbors := make(map[T]struct{})
+		for a := range preds[n] {
+			neighbors[a] = struct{}{}
+		}
+		for _, e := range edges {
+			neighbors[e.Dest] = struct{}{}
+		}
+		delete(neighbors, n)
+		if len(neighbors) != 2 {
+			continue
+		}
+		type inEdge struct {
+			from T
+			cost uint64
+		}
+		var ins []inEdge
+		for a := range preds[n] {
+			if a == n {
+				continue
+			}
+			for _, e := range g.Nodes[a] {
+				if e.Dest == n {
+					ins = append(ins, inEdge{from: a, cost: e.Cost})
+				}
+			}
+		}
+		// a source or sink, such as the start of two one-way paths, has no path through it and must stay
+		through := false
+		for _, in := range ins {
+			for _, out := range edges {
+				if out.Dest != n && in.from != out.Dest {
+					through = true
+				}
+			}
+		}
+		if !through {
+			continue
+		}
+		for a := range preds[n] {
+			if a == n {
+				continue
+			}
+			var kept []Edge[T]
+			for _, e := range g.Nodes[a] {
+				if e.Dest != n {
+					kept = append(kept, e)
+				}
+			}
+			g.Nodes[a] = kept
+		}
+		for _, out := range edges {
+			if out.Dest == n {
+				continue
+			}
+			delete(preds[out.Dest], n)
+			for _, in := range ins {
+				if in.from != out.Dest {
+					addEdge(in.from, Edge[T]{Dest: out.Dest, Cost: in.cost + out.Cost})
+					addPred(out.Dest, in.from)
+				}
+			}
+		}
+		delete(g.Nodes, n)
+		delete(preds, n)
+		for a := range neighbors {
+			open = append(open, a)
+		}
+	}
+}
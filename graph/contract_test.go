@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// edgeCosts returns the sorted costs of the edges from one node to another
+func edgeCosts[T comparable](g *Graph[T], from, to T) []uint64 {
+	var costs []uint64
+	for _, e := range g.Nodes[from] {
+		if e.Dest == to {
+			costs = append(costs, e.Cost)
+		}
+	}
+	slices.Sort(costs)
+	return costs
+}
+
+// parallelCorridors returns a graph of S - A, then three corridors from A to B of lengths 3, 2 and 2, then B - E
+func parallelCorridors() *UndirectedGraph[string] {
+	g := &UndirectedGraph[string]{}
+	g.AddEdge("S", "A", 1)
+	g.AddEdge("A", "x1", 1)
+	g.AddEdge("x1", "x2", 1)
+	g.AddEdge("x2", "B", 1)
+	g.AddEdge("A", "y1", 1)
+	g.AddEdge("y1", "B", 1)
+	g.AddEdge("A", "z1", 1)
+	g.AddEdge("z1", "B", 1)
+	g.AddEdge("B", "E", 1)
+	return g
+}
+
+func TestContractParallelCorridors(t *testing.T) {
+	g := parallelCorridors()
+	g.ContractCorridors(func(n string) bool {
+		return n == "A" || n == "B"
+	})
+	var nodes []string
+	for n := range g.AllNodes() {
+		nodes = append(nodes, n)
+	}
+	slices.Sort(nodes)
+	if !slices.Equal(nodes, []string{"A", "B", "E", "S"}) {
+		t.Fatalf("expected only junctions and dead ends to remain, got %v", nodes)
+	}
+	if c := edgeCosts(&g.Graph, "A", "B"); !slices.Equal(c, []uint64{2, 3}) {
+		t.Errorf("expected edges from A to B of cost 2 and 3, got %v", c)
+	}
+	if c := edgeCosts(&g.Graph, "B", "A"); !slices.Equal(c, []uint64{2, 3}) {
+		t.Errorf("expected edges from B to A of cost 2 and 3, got %v", c)
+	}
+	dist, _ := g.Dijkstra("S")
+	if dist["E"] != 4 {
+		t.Errorf("expected a shortest distance of 4, got %d", dist["E"])
+	}
+
+	// once the corridors are gone, A and B only have two neighbors each, so they are contracted too, and the
+	// parallel edges carry through to give both the shortest and longest paths
+	g = parallelCorridors()
+	g.ContractCorridors(nil)
+	if len(g.Nodes) != 2 {
+		t.Errorf("expected only S and E to remain, got %v", g.Nodes)
+	}
+	if c := edgeCosts(&g.Graph, "S", "E"); !slices.Equal(c, []uint64{4, 5}) {
+		t.Errorf("expected edges from S to E of cost 4 and 5, got %v", c)
+	}
+}
+
+func TestContractOneWay(t *testing.T) {
+	// J1 -> a -> b -> J2, where the junctions also have dead ends hanging off them
+	g := &DirectedGraph[string]{}
+	g.AddEdge("J1", "a", 1)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "J2", 1)
+	for _, n := range []string{"J1", "J2"} {
+		for _, d := range []string{"d1", "d2"} {
+			g.AddEdge(n, n+d, 1)
+			g.AddEdge(n+d, n, 1)
+		}
+	}
+	g.ContractCorridors(nil)
+	if c := edgeCosts(&g.Graph, "J1", "J2"); !slices.Equal(c, []uint64{3}) {
+		t.Errorf("expected one edge from J1 to J2 of cost 3, got %v", c)
+	}
+	if c := edgeCosts(&g.Graph, "J2", "J1"); len(c) != 0 {
+		t.Errorf("expected no edge from J2 to J1, got %v", c)
+	}
+}
+
+func TestContractSourceAndSink(t *testing.T) {
+	// S -> A -> E and S -> B -> E, where S and E each have two neighbors but no path passes through them
+	g := &DirectedGraph[string]{}
+	g.AddEdge("S", "A", 1)
+	g.AddEdge("S", "B", 2)
+	g.AddEdge("A", "E", 1)
+	g.AddEdge("B", "E", 1)
+	g.ContractCorridors(nil)
+	var nodes []string
+	for n := range g.AllNodes() {
+		nodes = append(nodes, n)
+	}
+	slices.Sort(nodes)
+	if !slices.Equal(nodes, []string{"E", "S"}) {
+		t.Fatalf("expected the source and sink to remain, got %v", g.Nodes)
+	}
+	if c := edgeCosts(&g.Graph, "S", "E"); !slices.Equal(c, []uint64{2, 3}) {
+		t.Errorf("expected edges from S to E of cost 2 and 3, got %v", c)
+	}
+	dist, _ := g.Dijkstra("S")
+	if dist["E"] != 2 {
+		t.Errorf("expected a shortest distance of 2, got %d", dist["E"])
+	}
+}
@@ -3,6 +3,7 @@ package graph
 import (
 	"fmt"
 	utils "github.com/ghjm/advent_utils"
+	"iter"
 	"math"
 )
 
@@ -53,6 +54,28 @@ func (g *UndirectedGraph[T]) AddEdge(from, to T, cost uint64) {
 	g.Graph.AddEdge(to, from, cost)
 }
 
+// AllNodes returns an iterator over the nodes of the graph
+func (g *Graph[T]) AllNodes() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range g.Nodes {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the destinations and costs of the edges leaving a node
+func (g *Graph[T]) Neighbors(node T) iter.Seq2[T, uint64] {
+	return func(yield func(T, uint64) bool) {
+		for _, e := range g.Nodes[node] {
+			if !yield(e.Dest, e.Cost) {
+				return
+			}
+		}
+	}
+}
+
 // BuildStateGraph builds a graph by adding states to an already-existing graph, using a transition function
 func (g *DirectedGraph[T]) BuildStateGraph(transitionFunc func(T) []Edge[T]) {
 	g.checkInit()
@@ -0,0 +1,201 @@
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	utils "github.com/ghjm/advent_utils"
+)
+
+// Example is a puzzle example input along with its expected answers.  A nil answer means the example does not
+// apply to that part.
+type Example struct {
+	Name  string
+	Input string
+	Part1 any
+	Part2 any
+	err   error
+}
+
+// Day collects the solutions and examples for one puzzle day
+type Day struct {
+	Year       int
+	Day        int
+	Part1      utils.Solution
+	Part2      utils.Solution
+	Examples   []Example
+	LedgerPath string
+}
+
+// Case is a single entry in the test table generated for a Day
+type Case struct {
+	Name     string
+	Part     int
+	Solution utils.Solution
+	Example  *Example
+	Want     any
+}
+
+// NewDay allocates and initializes a new Day.  Either solution may be nil if that part is not written yet.
+func NewDay(year, day int, part1, part2 utils.Solution) *Day {
+	return &Day{
+		Year:  year,
+		Day:   day,
+		Part1: part1,
+		Part2: part2,
+	}
+}
+
+// AddExample adds an example with its expected answers
+func (d *Day) AddExample(name, input string, part1, part2 any) *Day {
+	d.Examples = append(d.Examples, Example{
+		Name:  name,
+		Input: input,
+		Part1: part1,
+		Part2: part2,
+	})
+	return d
+}
+
+// AddExampleFile adds an example whose input is read from a file in an fs.FS, such as an embed.FS.  Errors reading
+// the file are reported when the example is run.
+func (d *Day) AddExampleFile(fsys fs.FS, filename string, part1, part2 any) *Day {
+	data, err := fs.ReadFile(fsys, filename)
+	d.Examples = append(d.Examples, Example{
+		Name:  strings.TrimSuffix(path.Base(filename), ".txt"),
+		Input: string(data),
+		Part1: part1,
+		Part2: part2,
+		err:   err,
+	})
+	return d
+}
+
+// Cases returns the test table for this day: every example for each part it applies to, followed by the real
+// input for each part.  The expected answers for the real input are taken from the answer ledger, and are nil
+// if no correct answer has been recorded.
+func (d *Day) Cases() ([]Case, error) {
+	var cases []Case
+	for part, sol := range []utils.Solution{d.Part1, d.Part2} {
+		if sol == nil {
+			continue
+		}
+		for i := range d.Examples {
+			ex := &d.Examples[i]
+			want := ex.Part1
+			if part == 1 {
+				want = ex.Part2
+			}
+			if want == nil {
+				continue
+			}
+			cases = append(cases, Case{
+				Name:     fmt.Sprintf("part%d/example/%s", part+1, ex.Name),
+				Part:     part + 1,
+				Solution: sol,
+				Example:  ex,
+				Want:     want,
+			})
+		}
+	}
+	ledger, err := d.loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	for part, sol := range []utils.Solution{d.Part1, d.Part2} {
+		if sol == nil {
+			continue
+		}
+		c := Case{
+			Name:     fmt.Sprintf("part%d/input", part+1),
+			Part:     part + 1,
+			Solution: sol,
+		}
+		if ledger != nil {
+			if ans, ok := ledger.CorrectAnswer(d.Year, d.Day, part+1); ok {
+				c.Want = ans
+			}
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+// loadLedger loads the answer ledger, returning nil if there is no input directory to find it in.  Any other
+// failure to find the ledger, such as a locator that reads from an fs.FS, is an error.
+func (d *Day) loadLedger() (*utils.AnswerLedger, error) {
+	path := d.LedgerPath
+	if path == "" {
+		var err error
+		path, err = utils.DefaultLedgerPath()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("finding the answer ledger (set LedgerPath to choose one): %w", err)
+		}
+	}
+	return utils.LoadAnswerLedger(path)
+}
+
+// Run runs a single case and returns the answer.  Examples are run by temporarily replacing the global input
+// locator, so cases must not be run in parallel.
+func (d *Day) Run(c Case) (any, error) {
+	name := utils.DayInputName(d.Year, d.Day)
+	if c.Example == nil {
+		return c.Solution(name)
+	}
+	if c.Example.err != nil {
+		return nil, c.Example.err
+	}
+	old := utils.GetInputLocator()
+	defer utils.SetInputLocator(old)
+	utils.SetInputLocator(old.Clone(utils.WithInputFS(fstest.MapFS{name: {Data: []byte(c.Example.Input)}})))
+	return c.Solution(name)
+}
+
+// Test runs every case as a subtest.  Cases for the real input are skipped if the input is not available, and
+// their answer is logged if no correct answer has been recorded.
+func (d *Day) Test(t *testing.T) {
+	t.Helper()
+	cases, err := d.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			got, err := d.Run(c)
+			if c.Example == nil && errors.Is(err, fs.ErrNotExist) {
+				t.Skipf("input not available: %v", err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Want == nil {
+				t.Logf("answer: %v (no correct answer recorded)", got)
+				return
+			}
+			want := fmt.Sprint(c.Want)
+			have := fmt.Sprint(got)
+			if want != have {
+				t.Errorf("wrong answer (-want +got):\n%s", Diff(want, have))
+			}
+		})
+	}
+}
+
+// Registered creates a Day from the solutions registered with utils.Register
+func Registered(year, day int) *Day {
+	d := NewDay(year, day, nil, nil)
+	if fn, ok := utils.RegisteredSolution(year, day, 1); ok {
+		d.Part1 = fn
+	}
+	if fn, ok := utils.RegisteredSolution(year, day, 2); ok {
+		d.Part2 = fn
+	}
+	return d
+}
@@ -0,0 +1,164 @@
+package harness
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	utils "github.com/ghjm/advent_utils"
+)
+
+// countLines is a solution that returns the number of lines in its input
+func countLines(input string) (any, error) {
+	n := 0
+	err := utils.OpenAndReadLines(input, func(string) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// sumInts is a solution that returns the sum of the integers in its input
+func sumInts(input string) (any, error) {
+	rows, err := utils.OpenAndReadInts(input)
+	if err != nil {
+		return nil, err
+	}
+	sum := 0
+	for _, r := range rows {
+		for _, v := range r {
+			sum += v
+		}
+	}
+	return sum, nil
+}
+
+// useLocator replaces the global input locator for the duration of a test
+func useLocator(t *testing.T, l *utils.InputLocator) {
+	t.Helper()
+	old := utils.GetInputLocator()
+	utils.SetInputLocator(l)
+	t.Cleanup(func() {
+		utils.SetInputLocator(old)
+	})
+}
+
+func TestCases(t *testing.T) {
+	dir := t.TempDir()
+	ledgerPath := filepath.Join(dir, "answers.json")
+	ledger, err := utils.LoadAnswerLedger(ledgerPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger.Record(utils.LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "41", Verdict: utils.VerdictIncorrect})
+	ledger.Record(utils.LedgerEntry{Year: 2023, Day: 1, Part: 1, Answer: "42", Verdict: utils.VerdictCorrect})
+	err = ledger.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	examples := fstest.MapFS{"examples/example1.txt": {Data: []byte("1\n2\n")}}
+	d := NewDay(2023, 1, countLines, sumInts).
+		AddExample("small", "1 2\n3\n", 2, 6).
+		AddExampleFile(examples, "examples/example1.txt", nil, 3)
+	d.LedgerPath = ledgerPath
+	cases, err := d.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		name string
+		want any
+	}{
+		{"part1/example/small", 2},
+		{"part2/example/small", 6},
+		{"part2/example/example1", 3},
+		{"part1/input", "42"},
+		{"part2/input", nil},
+	}
+	if len(cases) != len(want) {
+		t.Fatalf("expected %d cases, got %d", len(want), len(cases))
+	}
+	for i, w := range want {
+		if cases[i].Name != w.name || cases[i].Want != w.want {
+			t.Errorf("case %d: expected %s = %v, got %s = %v", i, w.name, w.want, cases[i].Name, cases[i].Want)
+		}
+	}
+}
+
+func TestCasesLedgerErrors(t *testing.T) {
+	d := NewDay(2023, 1, countLines, nil)
+	useLocator(t, utils.NewInputLocator(utils.WithInputFS(fstest.MapFS{})))
+	_, err := d.Cases()
+	if err == nil || !strings.Contains(err.Error(), "LedgerPath") {
+		t.Errorf("expected an error for a locator without a directory, got %v", err)
+	}
+	useLocator(t, utils.NewInputLocator(utils.WithInputEnvVar(""), utils.WithInputDir("no-such-input-dir")))
+	cases, err := d.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 1 || cases[0].Want != nil {
+		t.Errorf("expected one input case with no answer, got %+v", cases)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	name := utils.DayInputName(2023, 1)
+	err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, name), []byte("1\n2\n3\n4\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := utils.NewInputLocator(utils.WithInputEnvVar(""), utils.WithInputDir(dir))
+	useLocator(t, l)
+	d := NewDay(2023, 1, countLines, nil).AddExample("small", "a\nb\n", 2, nil)
+	d.LedgerPath = filepath.Join(dir, "answers.json")
+	cases, err := d.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.Run(cases[0])
+	if err != nil || got != 2 {
+		t.Errorf("expected the example to give 2, got %v, %v", got, err)
+	}
+	if utils.GetInputLocator() != l {
+		t.Errorf("expected Run to restore the input locator")
+	}
+	got, err = d.Run(cases[1])
+	if err != nil || got != 4 {
+		t.Errorf("expected the real input to give 4, got %v, %v", got, err)
+	}
+	bad := NewDay(2023, 1, countLines, nil).AddExampleFile(fstest.MapFS{}, "missing.txt", 1, nil)
+	bad.LedgerPath = d.LedgerPath
+	cases, err = bad.Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bad.Run(cases[0]); err == nil {
+		t.Errorf("expected an error for a missing example file")
+	}
+	d.Test(t)
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		want, got string
+		diff      string
+	}{
+		{"42", "42", "  42\n"},
+		{"42", "43", "- 42\n+ 43\n"},
+		{"a\nb\nc", "a\nc\nd", "  a\n- b\n  c\n+ d\n"},
+		{"", "x", "- \n+ x\n"},
+	}
+	for _, tt := range tests {
+		if d := Diff(tt.want, tt.got); d != tt.diff {
+			t.Errorf("Diff(%q, %q): expected %q, got %q", tt.want, tt.got, tt.diff, d)
+		}
+	}
+}
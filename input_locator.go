@@ -0,0 +1,236 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// InputLocator resolves puzzle input names to files
+type InputLocator struct {
+	InputLocatorOptions
+}
+
+// InputLocatorOptions collects extra options when initializing an InputLocator
+type InputLocatorOptions struct {
+	fsys      fs.FS
+	envVar    string
+	dir       string
+	searchUp  bool
+	dayLayout string
+	fetcher   InputFetcher
+}
+
+// InputFetcher downloads a missing per-day input into a destination file
+type InputFetcher interface {
+	FetchInput(year, day int, dest string) error
+}
+
+// DefaultInputEnvVar is the environment variable that, if set, names the directory containing puzzle inputs
+const DefaultInputEnvVar = "ADVENT_INPUTS"
+
+// DefaultInputDir is the name of the directory searched for puzzle inputs
+const DefaultInputDir = "inputs"
+
+// DefaultDayLayout is the format used by DayInputName, taking the year and day as arguments
+const DefaultDayLayout = "%d/day%02d.txt"
+
+// WithInputFS reads inputs from an fs.FS, such as an embed.FS or fstest.MapFS, instead of the OS filesystem
+func WithInputFS(fsys fs.FS) func(*InputLocatorOptions) {
+	return func(options *InputLocatorOptions) {
+		options.fsys = fsys
+	}
+}
+
+// WithInputEnvVar sets the environment variable consulted for the input directory.  An empty name disables it.
+func WithInputEnvVar(name string) func(*InputLocatorOptions) {
+	return func(options *InputLocatorOptions) {
+		options.envVar = name
+	}
+}
+
+// WithInputDir sets the input directory.  If the directory is relative and searching up is enabled, each parent
+// of the working directory is also checked for it.
+func WithInputDir(dir string) func(*InputLocatorOptions) {
+	return func(options *InputLocatorOptions) {
+		options.dir = dir
+	}
+}
+
+// WithSearchUp enables or disables searching parent directories for the input directory
+func WithSearchUp(searchUp bool) func(*InputLocatorOptions) {
+	return func(options *InputLocatorOptions) {
+		options.searchUp = searchUp
+	}
+}
+
+// WithDayLayout sets the format used to name per-day inputs.  It is given the year and day, in that order.
+func WithDayLayout(layout string) func(*InputLocatorOptions) {
+	return func(options *InputLocatorOptions) {
+		options.dayLayout = layout
+	}
+}
+
+// WithFetcher sets a fetcher that is used to download per-day inputs that are missing from the input directory
+func WithFetcher(fetcher InputFetcher) func(*InputLocatorOptions) {
+	return func(options *InputLocatorOptions) {
+		options.fetcher = fetcher
+	}
+}
+
+// NewInputLocator allocates and initializes a new InputLocator
+func NewInputLocator(options ...func(*InputLocatorOptions)) *InputLocator {
+	l := InputLocator{
+		InputLocatorOptions: InputLocatorOptions{
+			envVar:    DefaultInputEnvVar,
+			dir:       DefaultInputDir,
+			searchUp:  true,
+			dayLayout: DefaultDayLayout,
+		},
+	}
+	for _, opt := range options {
+		opt(&l.InputLocatorOptions)
+	}
+	return &l
+}
+
+// Clone returns a copy of the locator with additional options applied
+func (l *InputLocator) Clone(options ...func(*InputLocatorOptions)) *InputLocator {
+	nl := InputLocator{
+		InputLocatorOptions: l.InputLocatorOptions,
+	}
+	for _, opt := range options {
+		opt(&nl.InputLocatorOptions)
+	}
+	return &nl
+}
+
+var (
+	inputLocatorLock sync.RWMutex
+	inputLocator     = NewInputLocator()
+)
+
+// SetInputLocator replaces the locator used by OpenInputFile and the functions built on it
+func SetInputLocator(l *InputLocator) {
+	inputLocatorLock.Lock()
+	defer inputLocatorLock.Unlock()
+	inputLocator = l
+}
+
+// GetInputLocator returns the locator used by OpenInputFile and the functions built on it
+func GetInputLocator() *InputLocator {
+	inputLocatorLock.RLock()
+	defer inputLocatorLock.RUnlock()
+	return inputLocator
+}
+
+// DayInputName returns the input name for a given year and day, using the current locator's layout
+func DayInputName(year, day int) string {
+	return GetInputLocator().DayName(year, day)
+}
+
+// DayName returns the input name for a given year and day
+func (l *InputLocator) DayName(year, day int) string {
+	return fmt.Sprintf(l.dayLayout, year, day)
+}
+
+// layoutVerb matches the integer verbs in a day layout
+var layoutVerb = regexp.MustCompile(`%[0-9]*d`)
+
+// ParseDayName is the inverse of DayName.  The bool is returned false if the name does not match the layout.
+func (l *InputLocator) ParseDayName(name string) (year int, day int, ok bool) {
+	literals := layoutVerb.Split(l.dayLayout, -1)
+	if len(literals) != 3 {
+		return 0, 0, false
+	}
+	for i := range literals {
+		literals[i] = regexp.QuoteMeta(literals[i])
+	}
+	re, err := regexp.Compile("^" + literals[0] + `(\d+)` + literals[1] + `(\d+)` + literals[2] + "$")
+	if err != nil {
+		return 0, 0, false
+	}
+	m := re.FindStringSubmatch(name)
+	if m == nil {
+		return 0, 0, false
+	}
+	year, _ = strconv.Atoi(m[1])
+	day, _ = strconv.Atoi(m[2])
+	return year, day, true
+}
+
+// Dir returns the OS directory that inputs are read from.  It is an error to call this when reading from an fs.FS.
+func (l *InputLocator) Dir() (string, error) {
+	if l.fsys != nil {
+		return "", fmt.Errorf("input locator reads from an fs.FS, not a directory")
+	}
+	if l.envVar != "" {
+		if dir := os.Getenv(l.envVar); dir != "" {
+			return dir, nil
+		}
+	}
+	if filepath.IsAbs(l.dir) || !l.searchUp {
+		return l.dir, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for cur := wd; ; {
+		candidate := filepath.Join(cur, l.dir)
+		st, err := os.Stat(candidate)
+		if err == nil && st.IsDir() {
+			return candidate, nil
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			break
+		}
+		cur = parent
+	}
+	return "", fmt.Errorf("no %s directory found in %s or any parent: %w", l.dir, wd, fs.ErrNotExist)
+}
+
+// Open opens the named input
+func (l *InputLocator) Open(name string) (fs.File, error) {
+	if l.fsys != nil {
+		return l.fsys.Open(path.Clean(name))
+	}
+	dir, err := l.Dir()
+	if err != nil {
+		if l.fetcher == nil || !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		dir, err = filepath.Abs(l.dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filename := filepath.Join(dir, filepath.FromSlash(name))
+	f, err := os.Open(filename)
+	if err == nil {
+		return f, nil
+	}
+	if l.fetcher == nil || !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	year, day, ok := l.ParseDayName(name)
+	if !ok {
+		return nil, err
+	}
+	err = l.fetcher.FetchInput(year, day, filename)
+	if err != nil {
+		return nil, err
+	}
+	f, err = os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
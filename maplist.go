@@ -1,6 +1,10 @@
 package utils
 
-import "golang.org/x/exp/maps"
+import (
+	"iter"
+
+	"golang.org/x/exp/maps"
+)
 
 type MapList[KT comparable, VT any] struct {
 	data map[KT][]VT
@@ -48,6 +52,17 @@ func (ml *MapList[KT, VT]) Keys() []KT {
 	return maps.Keys(ml.data)
 }
 
+// All returns an iterator over each key of the map and its list
+func (ml *MapList[KT, VT]) All() iter.Seq2[KT, []VT] {
+	return func(yield func(KT, []VT) bool) {
+		for k, v := range ml.data {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
 // Contains returns true if the key is in the map
 func (ml *MapList[KT, VT]) Contains(k KT) bool {
 	if ml.data == nil {
@@ -0,0 +1,358 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError reports a failure to convert a captured field to its struct field type
+type ParseError struct {
+	Line   int
+	Column int
+	Field  string
+	Text   string
+	Err    error
+}
+
+// Error returns the error message
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: field %s: cannot parse %q: %v", e.Line, e.Column, e.Field, e.Text, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parsedField maps a named capture group to a struct field
+type parsedField struct {
+	name  string
+	index []int
+	group int
+	sep   string
+}
+
+// lineParser fills structs of a given type from the named capture groups of a regex
+type lineParser struct {
+	re     *regexp.Regexp
+	typ    reflect.Type
+	fields []parsedField
+}
+
+var stdPointType = reflect.TypeOf(StdPoint{})
+
+// newLineParser creates a lineParser.  Each exported field of the struct is filled from the capture group named by
+// its `re` struct tag, or if there is no tag, the group whose name matches the field name (ignoring case).  A tag
+// of "-" skips the field.  Slice fields are split on the string given by the `sep` tag, or by default on commas
+// and whitespace.
+func newLineParser(typ reflect.Type, regex string) (*lineParser, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot parse into non-struct type %s", typ)
+	}
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, err
+	}
+	lp := &lineParser{re: re, typ: typ}
+	groups := re.SubexpNames()
+	for _, sf := range reflect.VisibleFields(typ) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		name, hasTag := sf.Tag.Lookup("re")
+		if name == "-" {
+			continue
+		}
+		if !hasTag {
+			name = sf.Name
+		}
+		group := -1
+		for i, g := range groups {
+			if g == name || (!hasTag && g != "" && strings.EqualFold(g, name)) {
+				group = i
+				break
+			}
+		}
+		if group < 0 {
+			if hasTag {
+				return nil, fmt.Errorf("field %s: no capture group named %s", sf.Name, name)
+			}
+			continue
+		}
+		err = checkParseType(sf.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		lp.fields = append(lp.fields, parsedField{
+			name:  sf.Name,
+			index: sf.Index,
+			group: group,
+			sep:   sf.Tag.Get("sep"),
+		})
+	}
+	return lp, nil
+}
+
+// checkParseType returns an error if values of a type cannot be parsed from a string
+func checkParseType(t reflect.Type) error {
+	if t == stdPointType {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Slice {
+			return fmt.Errorf("nested slices are not supported")
+		}
+		return checkParseType(t.Elem())
+	}
+	return fmt.Errorf("unsupported type %s", t)
+}
+
+// parse matches a line and fills a new struct from it.  The bool is returned false if the line does not match.
+func (lp *lineParser) parse(line string, lineNum int) (reflect.Value, bool, error) {
+	m := lp.re.FindStringSubmatchIndex(line)
+	if m == nil {
+		return reflect.Value{}, false, nil
+	}
+	v := reflect.New(lp.typ).Elem()
+	for _, f := range lp.fields {
+		start, end := m[2*f.group], m[2*f.group+1]
+		if start < 0 {
+			continue
+		}
+		col, err := setParsedValue(v.FieldByIndex(f.index), line[start:end], f.sep)
+		if err != nil {
+			return reflect.Value{}, true, &ParseError{
+				Line:   lineNum,
+				Column: start + col + 1,
+				Field:  f.name,
+				Text:   line[start:end],
+				Err:    err,
+			}
+		}
+	}
+	return v, true, nil
+}
+
+var (
+	signedIntRegex  = regexp.MustCompile(`[-+]?\d+`)
+	defaultSepRegex = regexp.MustCompile(`[^\s,]+`)
+)
+
+// setParsedValue parses a string into a value.  On error, it returns the offset within s where the error occurred.
+func setParsedValue(v reflect.Value, s string, sep string) (int, error) {
+	t := v.Type()
+	if t == stdPointType {
+		ints := signedIntRegex.FindAllString(s, -1)
+		if len(ints) != 2 {
+			return 0, fmt.Errorf("expected 2 integers for a point, found %d", len(ints))
+		}
+		idx := signedIntRegex.FindAllStringIndex(s, -1)
+		var xy [2]int
+		for i := range xy {
+			var err error
+			xy[i], err = strconv.Atoi(ints[i])
+			if err != nil {
+				return idx[i][0], err
+			}
+		}
+		v.Set(reflect.ValueOf(StdPoint{X: xy[0], Y: xy[1]}))
+		return 0, nil
+	}
+	switch t.Kind() {
+	case reflect.Int32:
+		// rune is an alias for int32, so they cannot be told apart, and int32 is taken to mean rune
+		r, size := utf8.DecodeRuneInString(s)
+		if size == 0 || size != len(s) {
+			return 0, fmt.Errorf("expected a single rune (int32 fields are parsed as runes)")
+		}
+		v.SetInt(int64(r))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, t.Bits())
+		if err != nil {
+			return 0, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, t.Bits())
+		if err != nil {
+			return 0, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(s), t.Bits())
+		if err != nil {
+			return 0, err
+		}
+		v.SetFloat(n)
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Slice:
+		return setParsedSlice(v, s, sep)
+	default:
+		return 0, fmt.Errorf("unsupported type %s", t)
+	}
+	return 0, nil
+}
+
+// setParsedSlice splits a string and parses each element into a slice
+func setParsedSlice(v reflect.Value, s string, sep string) (int, error) {
+	et := v.Type().Elem()
+	var spans [][]int
+	switch {
+	case sep != "":
+		pos := 0
+		for _, part := range strings.Split(s, sep) {
+			trimmed := strings.TrimSpace(part)
+			if trimmed != "" {
+				lead := len(part) - len(strings.TrimLeftFunc(part, unicode.IsSpace))
+				spans = append(spans, []int{pos + lead, pos + lead + len(trimmed)})
+			}
+			pos += len(part) + len(sep)
+		}
+	case et == stdPointType:
+		ints := signedIntRegex.FindAllStringIndex(s, -1)
+		if len(ints)%2 != 0 {
+			return 0, fmt.Errorf("odd number of integers for a list of points")
+		}
+		for i := 0; i < len(ints); i += 2 {
+			spans = append(spans, []int{ints[i][0], ints[i+1][1]})
+		}
+	case et.Kind() == reflect.Int32:
+		for i := range s {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			spans = append(spans, []int{i, i + size})
+		}
+	default:
+		spans = defaultSepRegex.FindAllStringIndex(s, -1)
+	}
+	sl := reflect.MakeSlice(v.Type(), len(spans), len(spans))
+	for i, sp := range spans {
+		col, err := setParsedValue(sl.Index(i), s[sp[0]:sp[1]], "")
+		if err != nil {
+			return sp[0] + col, err
+		}
+	}
+	v.Set(sl)
+	return 0, nil
+}
+
+// ParseStrings matches each string against a regex with named capture groups, and fills a T from each match.
+// T must be a struct.  Fields may be any integer or float type, string, rune, StdPoint, or a slice of these.
+// Because rune is an alias for int32, an int32 field is parsed as a single rune rather than a number, and a []rune
+// field holds each character of the capture; use int or int64 for numbers.
+func ParseStrings[T any](lines []string, regex string, allMustMatch bool) ([]T, error) {
+	lp, err := newLineParser(reflect.TypeFor[T](), regex)
+	if err != nil {
+		return nil, err
+	}
+	var results []T
+	for i, line := range lines {
+		v, ok, err := lp.parse(line, i+1)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if allMustMatch {
+				return nil, fmt.Errorf("line %d: non-matching line: %s", i+1, line)
+			}
+			continue
+		}
+		results = append(results, v.Interface().(T))
+	}
+	return results, nil
+}
+
+// ParseLines reads a file, matching each line against a regex with named capture groups and filling a T from each
+// match.  See ParseStrings for the supported field types.
+func ParseLines[T any](name string, regex string, allMustMatch bool) ([]T, error) {
+	var lines []string
+	err := OpenAndReadLines(name, func(s string) error {
+		lines = append(lines, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ParseStrings[T](lines, regex, allMustMatch)
+}
+
+// MustParseLines reads and parses a file as with ParseLines, and panics on any error
+func MustParseLines[T any](name string, regex string, allMustMatch bool) []T {
+	results, err := ParseLines[T](name, regex, allMustMatch)
+	if err != nil {
+		panic(err)
+	}
+	return results
+}
+
+// TypedRegex is a regex alternative for OpenAndParseMultipleRegex, created with TypedMatch
+type TypedRegex interface {
+	compile() error
+	match(line string, lineNum int) (bool, error)
+}
+
+type typedRegex[T any] struct {
+	regex     string
+	matchFunc func(T) error
+	lp        *lineParser
+}
+
+// TypedMatch creates a TypedRegex that parses matching lines into a T and passes them to matchFunc
+func TypedMatch[T any](regex string, matchFunc func(T) error) TypedRegex {
+	return &typedRegex[T]{
+		regex:     regex,
+		matchFunc: matchFunc,
+	}
+}
+
+func (tr *typedRegex[T]) compile() error {
+	var err error
+	tr.lp, err = newLineParser(reflect.TypeFor[T](), tr.regex)
+	return err
+}
+
+func (tr *typedRegex[T]) match(line string, lineNum int) (bool, error) {
+	v, ok, err := tr.lp.parse(line, lineNum)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return true, tr.matchFunc(v.Interface().(T))
+}
+
+// OpenAndParseMultipleRegex is the typed equivalent of OpenAndReadMultipleRegex.  Each line is parsed by the first
+// alternative whose regex matches it.
+func OpenAndParseMultipleRegex(name string, regexes []TypedRegex, allMustMatch bool) error {
+	for _, rx := range regexes {
+		err := rx.compile()
+		if err != nil {
+			return err
+		}
+	}
+	lineNum := 0
+	return OpenAndReadLines(name, func(s string) error {
+		lineNum++
+		for _, rx := range regexes {
+			ok, err := rx.match(s, lineNum)
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+		}
+		if allMustMatch {
+			return fmt.Errorf("line %d: failed to match any regexes", lineNum)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type parsedRecord struct {
+	Name   string
+	Count  int
+	Big    int64
+	Mask   uint64
+	Weight float64
+	Dir    rune
+	Pos    StdPoint
+	Vals   []int
+	Path   []StdPoint
+	Tags   []string `sep:";"`
+	Bits   []rune
+	Ignore int `re:"-"`
+}
+
+func TestParseStrings(t *testing.T) {
+	const regex = `^(?P<name>\w+) (?P<count>-?\d+) (?P<big>\d+) (?P<mask>\d+) (?P<weight>[\d.]+) (?P<dir>[UDLR]) ` +
+		`p=(?P<pos>[-\d]+,[-\d]+) v=(?P<vals>[-\d, ]+) path=(?P<path>[-\d, ]+) tags=(?P<tags>[^ ]*) (?P<bits>[#.]+)$`
+	lines := []string{
+		"alpha -3 9000000000 18446744073709551615 2.5 U p=1,-2 v=1, 2,3 path=0,0, 4,-5 tags=a;b;;c #.#",
+		"not a match",
+	}
+	got, err := ParseStrings[parsedRecord](lines, regex, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []parsedRecord{{
+		Name:   "alpha",
+		Count:  -3,
+		Big:    9000000000,
+		Mask:   18446744073709551615,
+		Weight: 2.5,
+		Dir:    'U',
+		Pos:    StdPoint{X: 1, Y: -2},
+		Vals:   []int{1, 2, 3},
+		Path:   []StdPoint{{X: 0, Y: 0}, {X: 4, Y: -5}},
+		Tags:   []string{"a", "b", "c"},
+		Bits:   []rune{'#', '.', '#'},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	_, err = ParseStrings[parsedRecord](lines, regex, true)
+	if err == nil {
+		t.Errorf("expected an error for a non-matching line")
+	}
+}
+
+func TestParseStringsErrors(t *testing.T) {
+	type rec struct {
+		A int
+		B []int
+		P StdPoint
+		R rune
+	}
+	tests := []struct {
+		line   string
+		field  string
+		column int
+	}{
+		{"a=12x b=1 p=0,0 r=x", "A", 3},
+		{"a=1 b=1,2,zz,4 p=0,0 r=x", "B", 11},
+		{"a=1 b=1 p=5,99999999999999999999 r=x", "P", 13},
+		{"a=1 b=1 p=0,0 r=123", "R", 17},
+	}
+	for _, tt := range tests {
+		_, err := ParseStrings[rec]([]string{"", tt.line}, `a=(?P<a>\S+) b=(?P<b>\S+) p=(?P<p>\S+) r=(?P<r>\S+)`, false)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("parsing %q: expected a ParseError, got %v", tt.line, err)
+			continue
+		}
+		if pe.Line != 2 || pe.Field != tt.field || pe.Column != tt.column {
+			t.Errorf("parsing %q: expected line 2, field %s, column %d, got %d, %s, %d", tt.line, tt.field,
+				tt.column, pe.Line, pe.Field, pe.Column)
+		}
+	}
+	var ne *strconv.NumError
+	_, err := ParseStrings[rec]([]string{"a=1 b=1 p=5,99999999999999999999 r=x"},
+		`a=(?P<a>\S+) b=(?P<b>\S+) p=(?P<p>\S+) r=(?P<r>\S+)`, false)
+	if !errors.As(err, &ne) || !errors.Is(ne.Err, strconv.ErrRange) {
+		t.Errorf("expected an out of range error for a point, got %v", err)
+	}
+	type bad struct {
+		M map[string]int `re:"m"`
+	}
+	_, err = ParseStrings[bad]([]string{"x"}, `(?P<m>x)`, false)
+	if err == nil {
+		t.Errorf("expected an error for an unsupported field type")
+	}
+	type missing struct {
+		A int `re:"nope"`
+	}
+	_, err = ParseStrings[missing]([]string{"x"}, `(?P<a>x)`, false)
+	if err == nil {
+		t.Errorf("expected an error for a tag naming a missing group")
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	useTestInputs(t, map[string]string{"input.txt": "move 3 from 1 to 2\nmove x from 1 to 2\n"})
+	type move struct {
+		N, From, To int
+	}
+	_, err := ParseLines[move]("input.txt", `move (?P<n>\S+) from (?P<from>\d+) to (?P<to>\d+)`, true)
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Column != 6 {
+		t.Errorf("expected a parse error at line 2, column 6, got %v", err)
+	}
+	got := MustParseLines[move]("input.txt", `move (?P<n>\d+) from (?P<from>\d+) to (?P<to>\d+)`, false)
+	if len(got) != 1 || got[0] != (move{N: 3, From: 1, To: 2}) {
+		t.Errorf("unexpected result %+v", got)
+	}
+}
+
+func TestOpenAndParseMultipleRegex(t *testing.T) {
+	useTestInputs(t, map[string]string{"input.txt": "rect 3x2\nrotate row y=1 by 4\nnoop\n"})
+	type rect struct {
+		W, H int
+	}
+	type rotate struct {
+		Axis  string
+		Index int
+		By    int
+	}
+	var rects []rect
+	var rotates []rotate
+	alternatives := []TypedRegex{
+		TypedMatch(`^rect (?P<w>\d+)x(?P<h>\d+)$`, func(r rect) error {
+			rects = append(rects, r)
+			return nil
+		}),
+		TypedMatch(`^rotate \w+ (?P<axis>[xy])=(?P<index>\d+) by (?P<by>\d+)$`, func(r rotate) error {
+			rotates = append(rotates, r)
+			return nil
+		}),
+	}
+	err := OpenAndParseMultipleRegex("input.txt", alternatives, true)
+	if err == nil || err.Error() != "line 3: failed to match any regexes" {
+		t.Errorf("expected line 3 to fail to match, got %v", err)
+	}
+	rects, rotates = nil, nil
+	err = OpenAndParseMultipleRegex("input.txt", alternatives, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rects) != 1 || rects[0] != (rect{W: 3, H: 2}) {
+		t.Errorf("unexpected rects %+v", rects)
+	}
+	if len(rotates) != 1 || rotates[0] != (rotate{Axis: "y", Index: 1, By: 4}) {
+		t.Errorf("unexpected rotates %+v", rotates)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
-	"os"
+	"iter"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 type InputFileReader interface {
@@ -15,16 +17,20 @@ type InputFileReader interface {
 	ReadAll() ([]byte, error)
 	ReadLine() (line []byte, isPrefix bool, err error)
 	ReadLines(callback func(string) error) error
+	ReadSections() ([][]string, error)
+	Lines() iter.Seq[string]
+	Err() error
 }
 
 type inputFileReader struct {
-	file      *os.File
+	file      fs.File
 	bufreader *bufio.Reader
+	err       error
 }
 
+// OpenInputFile opens a named puzzle input, as resolved by the current InputLocator
 func OpenInputFile(name string) (InputFileReader, error) {
-	filename := fmt.Sprintf("./inputs/%s", name)
-	file, err := os.OpenFile(filename, os.O_RDONLY, 0)
+	file, err := GetInputLocator().Open(name)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +73,66 @@ func OpenAndReadLines(name string, callback func(string) error) error {
 	return nil
 }
 
+// OpenAndReadSections reads a file made of blocks of lines separated by blank lines
+func OpenAndReadSections(name string) ([][]string, error) {
+	ifr, err := OpenInputFile(name)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := ifr.ReadSections()
+	if err != nil {
+		return nil, err
+	}
+	err = ifr.Close()
+	if err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// OpenAndReadSectionsFunc reads a file made of blank-line-separated sections, passing the first section to the
+// first callback, the second to the second, and so on.  If there are more sections than callbacks, the last
+// callback receives all the remaining sections.
+func OpenAndReadSectionsFunc(name string, callbacks ...func([]string) error) error {
+	if len(callbacks) == 0 {
+		return fmt.Errorf("no section callbacks provided")
+	}
+	sections, err := OpenAndReadSections(name)
+	if err != nil {
+		return err
+	}
+	for i, sec := range sections {
+		err = callbacks[Min(i, len(callbacks)-1)](sec)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenAndReadSectionLines reads a file made of blank-line-separated sections, calling a different line callback
+// for each section.  If there are more sections than callbacks, the last callback receives all remaining lines.
+func OpenAndReadSectionLines(name string, callbacks ...func(string) error) error {
+	if len(callbacks) == 0 {
+		return fmt.Errorf("no section callbacks provided")
+	}
+	section := 0
+	started := false
+	inBlank := false
+	return OpenAndReadLines(name, func(s string) error {
+		if isBlankLine(s) {
+			inBlank = started
+			return nil
+		}
+		if inBlank && section < len(callbacks)-1 {
+			section++
+		}
+		started = true
+		inBlank = false
+		return callbacks[section](s)
+	})
+}
+
 func OpenAndReadRegex(name string, regex string, allMustMatch bool) ([][]string, error) {
 	re, err := regexp.Compile(regex)
 	if err != nil {
@@ -135,8 +201,17 @@ func StringsToInts(s []string, positions ...int) ([]int, error) {
 	return results, nil
 }
 
+// reader returns the buffered reader shared by all the read methods, so that none of them loses data buffered
+// by another
+func (ifr *inputFileReader) reader() *bufio.Reader {
+	if ifr.bufreader == nil {
+		ifr.bufreader = bufio.NewReader(ifr.file)
+	}
+	return ifr.bufreader
+}
+
 func (ifr *inputFileReader) Read(p []byte) (n int, err error) {
-	return ifr.bufreader.Read(p)
+	return ifr.reader().Read(p)
 }
 
 func (ifr *inputFileReader) Close() error {
@@ -144,27 +219,75 @@ func (ifr *inputFileReader) Close() error {
 }
 
 func (ifr *inputFileReader) ReadLine() (line []byte, isPrefix bool, err error) {
-	if ifr.bufreader == nil {
-		ifr.bufreader = bufio.NewReader(ifr.file)
-	}
-	return ifr.bufreader.ReadLine()
+	return ifr.reader().ReadLine()
 }
 
 func (ifr *inputFileReader) ReadAll() ([]byte, error) {
-	return ioutil.ReadAll(ifr.file)
+	return ioutil.ReadAll(ifr.reader())
+}
+
+// isBlankLine returns true if a line separates sections
+func isBlankLine(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// ReadSections reads the remainder of the file as blocks of lines separated by one or more blank lines
+func (ifr *inputFileReader) ReadSections() ([][]string, error) {
+	var sections [][]string
+	var cur []string
+	err := ifr.ReadLines(func(s string) error {
+		if isBlankLine(s) {
+			if cur != nil {
+				sections = append(sections, cur)
+				cur = nil
+			}
+			return nil
+		}
+		cur = append(cur, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cur != nil {
+		sections = append(sections, cur)
+	}
+	return sections, nil
+}
+
+// Lines returns an iterator over the remaining lines of the file.  If the loop stops early, the next read picks
+// up at the following line.  Any read error is available from Err once the iteration finishes.
+func (ifr *inputFileReader) Lines() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		r := ifr.reader()
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil && err != io.EOF {
+				ifr.err = err
+				return
+			}
+			if line == "" {
+				return
+			}
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+			if !yield(line) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered by Lines
+func (ifr *inputFileReader) Err() error {
+	return ifr.err
 }
 
 func (ifr *inputFileReader) ReadLines(callback func(string) error) error {
-	scanner := bufio.NewScanner(ifr.file)
-	var err error
-	for scanner.Scan() {
-		err = callback(scanner.Text())
+	for line := range ifr.Lines() {
+		err := callback(line)
 		if err != nil {
-			break
+			return err
 		}
 	}
-	if err == nil {
-		err = scanner.Err()
-	}
-	return err
+	return ifr.Err()
 }
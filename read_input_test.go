@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// useTestInputs makes the input functions read from an in-memory filesystem for the duration of a test
+func useTestInputs(t *testing.T, files map[string]string) {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	for name, data := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+	old := GetInputLocator()
+	SetInputLocator(NewInputLocator(WithInputFS(fsys)))
+	t.Cleanup(func() {
+		SetInputLocator(old)
+	})
+}
+
+func TestLinesResumesAfterBreak(t *testing.T) {
+	var sb strings.Builder
+	for i := range 2000 {
+		_, _ = fmt.Fprintf(&sb, "line %d\r\n", i)
+	}
+	useTestInputs(t, map[string]string{"input.txt": sb.String()})
+	ifr, err := OpenInputFile("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = ifr.Close()
+	}()
+	line, _, err := ifr.ReadLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(line) != "line 0" {
+		t.Errorf("expected line 0, got %q", line)
+	}
+	n := 1
+	for s := range ifr.Lines() {
+		if s != fmt.Sprintf("line %d", n) {
+			t.Fatalf("expected line %d, got %q", n, s)
+		}
+		n++
+		if n == 10 {
+			break
+		}
+	}
+	err = ifr.ReadLines(func(s string) error {
+		if s != fmt.Sprintf("line %d", n) {
+			return fmt.Errorf("expected line %d, got %q", n, s)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2000 {
+		t.Errorf("expected 2000 lines, got %d", n)
+	}
+}
+
+func TestLinesWithoutFinalNewline(t *testing.T) {
+	useTestInputs(t, map[string]string{"input.txt": "a\n\nb"})
+	ifr, err := OpenInputFile("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = ifr.Close()
+	}()
+	var lines []string
+	for s := range ifr.Lines() {
+		lines = append(lines, s)
+	}
+	if ifr.Err() != nil {
+		t.Fatal(ifr.Err())
+	}
+	if strings.Join(lines, "|") != "a||b" {
+		t.Errorf("unexpected lines %q", lines)
+	}
+}
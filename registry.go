@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// PuzzleDay identifies a single day's puzzle
+type PuzzleDay struct {
+	Year int
+	Day  int
+}
+
+// String returns a string value of the puzzle day
+func (pd PuzzleDay) String() string {
+	return fmt.Sprintf("%d/%02d", pd.Year, pd.Day)
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[PuzzleDay]map[int]Solution)
+)
+
+// Register records a solution for one part of a puzzle, so that it can be found by a runner.  It is meant to be
+// called from init functions, and panics if the part is invalid or already registered.
+func Register(year, day, part int, fn Solution) {
+	if part != 1 && part != 2 {
+		panic(fmt.Sprintf("invalid part %d", part))
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	pd := PuzzleDay{Year: year, Day: day}
+	parts, ok := registry[pd]
+	if !ok {
+		parts = make(map[int]Solution)
+		registry[pd] = parts
+	}
+	if _, ok := parts[part]; ok {
+		panic(fmt.Sprintf("%s part %d registered twice", pd, part))
+	}
+	parts[part] = fn
+}
+
+// RegisteredDays returns every day that has at least one registered solution, in order
+func RegisteredDays() []PuzzleDay {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	var results []PuzzleDay
+	for pd := range registry {
+		results = append(results, pd)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Year < results[j].Year || (results[i].Year == results[j].Year && results[i].Day < results[j].Day)
+	})
+	return results
+}
+
+// LatestRegisteredDay returns the most recent day with a registered solution.  The bool is returned false if
+// nothing is registered.
+func LatestRegisteredDay() (PuzzleDay, bool) {
+	days := RegisteredDays()
+	if len(days) == 0 {
+		return PuzzleDay{}, false
+	}
+	return days[len(days)-1], true
+}
+
+// RegisteredSolution returns the solution registered for one part of a puzzle.  The bool is returned false if
+// there is none.
+func RegisteredSolution(year, day, part int) (Solution, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	fn, ok := registry[PuzzleDay{Year: year, Day: day}][part]
+	return fn, ok
+}
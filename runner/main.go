@@ -0,0 +1,178 @@
+package runner
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	utils "github.com/ghjm/advent_utils"
+)
+
+// Main is the entry point of the aoc command.  It returns the process exit code.
+func Main(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "run":
+			return runCommand(args[1:], os.Stdout, os.Stderr)
+		case "new":
+			return newCommand(args[1:], os.Stdout, os.Stderr)
+		case "help", "-h", "-help", "--help":
+			usage(os.Stderr)
+			return 0
+		}
+	}
+	return runCommand(args, os.Stdout, os.Stderr)
+}
+
+// usage prints the top-level usage message
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "usage: aoc [run] [-all | -latest] [-json] [-j N] [year[/day] ...]\n")
+	_, _ = fmt.Fprintf(w, "       aoc new [-templates dir] [-dir dir] [-register file] <year> <day>\n")
+}
+
+// runCommand implements the run subcommand
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	all := flags.Bool("all", false, "run every registered day")
+	latest := flags.Bool("latest", false, "run the latest registered day (the default if no days are given)")
+	jsonOut := flags.Bool("json", false, "print results as JSON instead of a table")
+	parallel := flags.Int("j", runtime.GOMAXPROCS(0), "number of days to run in parallel; allocations are only reported when this is 1")
+	err := flags.Parse(args)
+	if err != nil {
+		return 2
+	}
+	days, err := selectDays(flags.Args(), *all, *latest)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "aoc: %v\n", err)
+		return 2
+	}
+	results := RunDays(days, *parallel)
+	if *jsonOut {
+		err = WriteJSON(stdout, results)
+	} else {
+		err = WriteTable(stdout, results)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "aoc: %v\n", err)
+		return 1
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			return 1
+		}
+	}
+	return 0
+}
+
+// selectDays works out which registered days to run from the command line
+func selectDays(args []string, all bool, latest bool) ([]utils.PuzzleDay, error) {
+	registered := utils.RegisteredDays()
+	if len(registered) == 0 {
+		return nil, fmt.Errorf("no solutions registered")
+	}
+	if all && latest {
+		return nil, fmt.Errorf("-all and -latest cannot be used together")
+	}
+	if (all || latest) && len(args) > 0 {
+		return nil, fmt.Errorf("-all and -latest cannot be used with explicit days")
+	}
+	if all {
+		return registered, nil
+	}
+	if latest || len(args) == 0 {
+		return registered[len(registered)-1:], nil
+	}
+	var days []utils.PuzzleDay
+	for _, arg := range args {
+		yearStr, dayStr, hasDay := strings.Cut(arg, "/")
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid year in %q", arg)
+		}
+		found := false
+		if hasDay {
+			day, err := strconv.Atoi(dayStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid day in %q", arg)
+			}
+			for _, pd := range registered {
+				if pd.Year == year && pd.Day == day {
+					days = append(days, pd)
+					found = true
+				}
+			}
+		} else {
+			for _, pd := range registered {
+				if pd.Year == year {
+					days = append(days, pd)
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no solutions registered for %s", arg)
+		}
+	}
+	return days, nil
+}
+
+// WriteTable prints results as an aligned table
+func WriteTable(w io.Writer, results []PartResult) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	_, err := fmt.Fprintf(tw, "YEAR\tDAY\tPART\tANSWER\tTIME\tALLOCS\tBYTES\n")
+	if err != nil {
+		return err
+	}
+	var total time.Duration
+	unmeasured := false
+	for _, r := range results {
+		answer := r.Answer
+		if r.Error != "" {
+			answer = "error: " + r.Error
+		}
+		answer = strings.ReplaceAll(answer, "\n", " ")
+		allocs, bytes := "-", "-"
+		unmeasured = unmeasured || !r.AllocsMeasured
+		if r.AllocsMeasured {
+			allocs, bytes = strconv.FormatUint(r.Allocs, 10), strconv.FormatUint(r.Bytes, 10)
+		}
+		_, err = fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\t%s\n", r.Year, r.Day, r.Part, answer,
+			r.Duration.Round(time.Microsecond), allocs, bytes)
+		if err != nil {
+			return err
+		}
+		total += r.Duration
+	}
+	if len(results) > 1 {
+		_, err = fmt.Fprintf(tw, "\t\t\ttotal\t%s\t\t\n", total.Round(time.Microsecond))
+		if err != nil {
+			return err
+		}
+	}
+	err = tw.Flush()
+	if err != nil {
+		return err
+	}
+	if unmeasured {
+		_, err = fmt.Fprintf(w, "\nallocations are only measured when days run one at a time; use -j 1 to see them\n")
+	}
+	return err
+}
+
+// WriteJSON prints results as a JSON array
+func WriteJSON(w io.Writer, results []PartResult) error {
+	if results == nil {
+		results = []PartResult{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
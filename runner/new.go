@@ -0,0 +1,228 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var defaultTemplates embed.FS
+
+// ScaffoldData is the data passed to the day templates
+type ScaffoldData struct {
+	Year       int
+	Day        int
+	Package    string
+	ModulePath string
+	ImportPath string
+}
+
+// Scaffold renders every .tmpl file in a template filesystem into a new package directory.  It refuses to
+// overwrite any existing file, and writes nothing if any output file already exists.
+func Scaffold(templates fs.FS, dir string, data ScaffoldData) ([]string, error) {
+	rendered := make(map[string][]byte)
+	var names []string
+	err := fs.WalkDir(templates, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".tmpl") {
+			return nil
+		}
+		src, err := fs.ReadFile(templates, p)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(p).Parse(string(src))
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, data)
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(dir, filepath.FromSlash(strings.TrimSuffix(p, ".tmpl")))
+		rendered[name] = buf.Bytes()
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no templates found")
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%s already exists and is not empty, refusing to overwrite", dir)
+	}
+	for _, name := range names {
+		if _, err := os.Stat(name); err == nil {
+			return nil, fmt.Errorf("%s already exists, refusing to overwrite", name)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	for _, name := range names {
+		err = os.MkdirAll(filepath.Dir(name), 0o755)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		_, err = f.Write(rendered[name])
+		if err == nil {
+			err = f.Close()
+		} else {
+			_ = f.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// findModule searches the working directory and its parents for go.mod, returning the module root and path
+func findModule() (string, string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+	for dir := wd; ; {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				fields := strings.Fields(scanner.Text())
+				if len(fields) == 2 && fields[0] == "module" {
+					return dir, strings.Trim(fields[1], `"`), nil
+				}
+			}
+			return "", "", fmt.Errorf("no module line in %s", filepath.Join(dir, "go.mod"))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", fmt.Errorf("no go.mod found in %s or any parent", wd)
+		}
+		dir = parent
+	}
+}
+
+// addRegistration adds a blank import of a day package to the import block of a Go file, so the day's
+// registrations are linked into the binary
+func addRegistration(filename string, importPath string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	line := fmt.Sprintf("\t_ %q\n", importPath)
+	src := string(data)
+	if strings.Contains(src, line) {
+		return nil
+	}
+	start := strings.Index(src, "import (")
+	if start < 0 {
+		return fmt.Errorf("%s has no import block", filename)
+	}
+	end := strings.Index(src[start:], ")")
+	if end < 0 {
+		return fmt.Errorf("%s has an unterminated import block", filename)
+	}
+	end += start
+	block := strings.TrimSuffix(src[start+len("import ("):end], "\n")
+	if strings.TrimSpace(block) == "" {
+		block = ""
+	}
+	src = src[:start] + "import (" + block + "\n" + line + src[end:]
+	return os.WriteFile(filename, []byte(src), 0o644)
+}
+
+// newCommand implements the new subcommand
+func newCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: aoc new [-templates dir] [-dir dir] [-register file] <year> <day>\n")
+		flags.PrintDefaults()
+	}
+	templateDir := flags.String("templates", "", "directory of .tmpl files to use instead of the built-in templates")
+	daysDir := flags.String("dir", "days", "directory, relative to the module root, that day packages are created in")
+	register := flags.String("register", "cmd/aoc/days.go", "file, relative to the module root, that imports day packages")
+	err := flags.Parse(args)
+	if err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+	year, err := strconv.Atoi(flags.Arg(0))
+	if err != nil || year < 2015 {
+		_, _ = fmt.Fprintf(stderr, "aoc: invalid year %q\n", flags.Arg(0))
+		return 2
+	}
+	day, err := strconv.Atoi(flags.Arg(1))
+	if err != nil || day < 1 || day > 25 {
+		_, _ = fmt.Fprintf(stderr, "aoc: invalid day %q\n", flags.Arg(1))
+		return 2
+	}
+	root, modulePath, err := findModule()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "aoc: %v\n", err)
+		return 1
+	}
+	var templates fs.FS
+	if *templateDir != "" {
+		templates = os.DirFS(*templateDir)
+	} else {
+		templates, err = fs.Sub(defaultTemplates, "templates")
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "aoc: %v\n", err)
+			return 1
+		}
+	}
+	pkg := fmt.Sprintf("day%02d", day)
+	rel := path.Join(filepath.ToSlash(*daysDir), strconv.Itoa(year), pkg)
+	data := ScaffoldData{
+		Year:       year,
+		Day:        day,
+		Package:    pkg,
+		ModulePath: modulePath,
+		ImportPath: path.Join(modulePath, rel),
+	}
+	names, err := Scaffold(templates, filepath.Join(root, filepath.FromSlash(rel)), data)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "aoc: %v\n", err)
+		return 1
+	}
+	for _, name := range names {
+		_, _ = fmt.Fprintf(stdout, "created %s\n", name)
+	}
+	regFile := filepath.Join(root, filepath.FromSlash(*register))
+	if _, err := os.Stat(regFile); err != nil {
+		_, _ = fmt.Fprintf(stdout, "%s not found; import %s to register the day\n", regFile, data.ImportPath)
+		return 0
+	}
+	err = addRegistration(regFile, data.ImportPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "aoc: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stdout, "registered %s in %s\n", data.ImportPath, regFile)
+	return 0
+}
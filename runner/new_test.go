@@ -0,0 +1,181 @@
+package runner
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// parseGo fails the test if a file is not valid Go source
+func parseGo(t *testing.T, filename string) {
+	t.Helper()
+	_, err := parser.ParseFile(token.NewFileSet(), filename, nil, parser.ImportsOnly)
+	if err != nil {
+		t.Errorf("%s is not valid Go: %v", filename, err)
+	}
+}
+
+func TestScaffold(t *testing.T) {
+	templates, err := fs.Sub(defaultTemplates, "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "days", "2023", "day05")
+	data := ScaffoldData{
+		Year:       2023,
+		Day:        5,
+		Package:    "day05",
+		ModulePath: "example.com/aoc",
+		ImportPath: "example.com/aoc/days/2023/day05",
+	}
+	names, err := Scaffold(templates, dir, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 3 {
+		t.Errorf("expected 3 files, got %v", names)
+	}
+	for _, name := range []string{"solution.go", "solution_test.go"} {
+		fn := filepath.Join(dir, name)
+		src, err := os.ReadFile(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(src), "package day05\n") {
+			t.Errorf("%s: expected package day05", name)
+		}
+		parseGo(t, fn)
+	}
+	src, _ := os.ReadFile(filepath.Join(dir, "solution.go"))
+	if !strings.Contains(string(src), "utils.Register(2023, 5, 1, Part1)") {
+		t.Errorf("expected the solution to register 2023 day 5")
+	}
+	src, _ = os.ReadFile(filepath.Join(dir, "solution_test.go"))
+	if !strings.Contains(string(src), "func TestDay05(") {
+		t.Errorf("expected a TestDay05 function")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "examples", "example1.txt")); err != nil {
+		t.Errorf("expected an empty example file: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "solution.go"), []byte("package day05\n// edited\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Scaffold(templates, dir, data)
+	if err == nil {
+		t.Errorf("expected an error scaffolding into an existing directory")
+	}
+	src, _ = os.ReadFile(filepath.Join(dir, "solution.go"))
+	if string(src) != "package day05\n// edited\n" {
+		t.Errorf("expected the existing file not to be overwritten")
+	}
+	_, err = Scaffold(fstest.MapFS{"README": {Data: []byte("x")}}, t.TempDir(), data)
+	if err == nil {
+		t.Errorf("expected an error when there are no templates")
+	}
+}
+
+func TestAddRegistration(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "empty",
+			src:  "package main\n\nimport ()\n",
+			want: "package main\n\nimport (\n\t_ \"example.com/aoc/days/2023/day01\"\n)\n",
+		},
+		{
+			name: "existing",
+			src:  "package main\n\n// Days\nimport (\n\t_ \"example.com/aoc/days/2022/day25\"\n)\n\nfunc f() {}\n",
+			want: "package main\n\n// Days\nimport (\n\t_ \"example.com/aoc/days/2022/day25\"\n" +
+				"\t_ \"example.com/aoc/days/2023/day01\"\n)\n\nfunc f() {}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := filepath.Join(t.TempDir(), "days.go")
+			err := os.WriteFile(fn, []byte(tt.src), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range 2 {
+				err = addRegistration(fn, "example.com/aoc/days/2023/day01")
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, _ := os.ReadFile(fn)
+			if string(got) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			parseGo(t, fn)
+		})
+	}
+	fn := filepath.Join(t.TempDir(), "days.go")
+	err := os.WriteFile(fn, []byte("package main\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = addRegistration(fn, "example.com/x"); err == nil {
+		t.Errorf("expected an error for a file without an import block")
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module example.com/aoc\n\ngo 1.23\n",
+		"cmd/aoc/days.go": "package main\n\nimport ()\n",
+	}
+	for name, src := range files {
+		fn := filepath.Join(root, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(fn), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(fn, []byte(src), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(filepath.Join(root, "cmd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	var stdout, stderr bytes.Buffer
+	if code := newCommand([]string{"2023", "7"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected success, got %d: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(root, "days", "2023", "day07", "solution.go")); err != nil {
+		t.Errorf("expected the day package to be created: %v", err)
+	}
+	reg, _ := os.ReadFile(filepath.Join(root, "cmd", "aoc", "days.go"))
+	if !strings.Contains(string(reg), "\t_ \"example.com/aoc/days/2023/day07\"\n") {
+		t.Errorf("expected the day to be registered, got %q", reg)
+	}
+	stderr.Reset()
+	if code := newCommand([]string{"2023", "7"}, &stdout, &stderr); code != 1 {
+		t.Errorf("expected failure scaffolding an existing day, got %d", code)
+	}
+	for _, args := range [][]string{{"2023"}, {"2023", "26"}, {"1999", "1"}} {
+		if code := newCommand(args, &stdout, &stderr); code != 2 {
+			t.Errorf("newCommand(%v): expected a usage error, got %d", args, code)
+		}
+	}
+}
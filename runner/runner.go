@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	utils "github.com/ghjm/advent_utils"
+)
+
+// PartResult is the outcome of running one part of a puzzle.  Allocs and Bytes are only set if AllocsMeasured is
+// true, because they cannot be measured while other days are running at the same time.
+type PartResult struct {
+	Year           int           `json:"year"`
+	Day            int           `json:"day"`
+	Part           int           `json:"part"`
+	Answer         string        `json:"answer,omitempty"`
+	Error          string        `json:"error,omitempty"`
+	Duration       time.Duration `json:"duration_ns"`
+	AllocsMeasured bool          `json:"allocs_measured"`
+	Allocs         uint64        `json:"allocs,omitempty"`
+	Bytes          uint64        `json:"bytes,omitempty"`
+}
+
+// RunPart runs the registered solution for one part of a puzzle against its real input, measuring wall-clock
+// time and allocations.  Allocation counts are process-wide, so they are only exact when nothing else is running.
+// A panic in the solution is reported as an error.
+func RunPart(year, day, part int) PartResult {
+	return runPart(year, day, part, true)
+}
+
+// runPart runs one part of a puzzle, measuring allocations only if requested
+func runPart(year, day, part int, measureAllocs bool) PartResult {
+	result := PartResult{Year: year, Day: day, Part: part}
+	fn, ok := utils.RegisteredSolution(year, day, part)
+	if !ok {
+		result.Error = "no solution registered"
+		return result
+	}
+	var before, after runtime.MemStats
+	if measureAllocs {
+		runtime.ReadMemStats(&before)
+	}
+	start := time.Now()
+	answer, err := callSolution(fn, utils.DayInputName(year, day))
+	result.Duration = time.Since(start)
+	if measureAllocs {
+		runtime.ReadMemStats(&after)
+		result.AllocsMeasured = true
+		result.Allocs = after.Mallocs - before.Mallocs
+		result.Bytes = after.TotalAlloc - before.TotalAlloc
+	}
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Answer = fmt.Sprint(answer)
+	}
+	return result
+}
+
+// callSolution calls a solution, converting a panic into an error
+func callSolution(fn utils.Solution, input string) (answer any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			answer = nil
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(input)
+}
+
+// RunDay runs both parts of a day, in order
+func RunDay(pd utils.PuzzleDay) []PartResult {
+	return runDay(pd, true)
+}
+
+// runDay runs both parts of a day, measuring allocations only if requested
+func runDay(pd utils.PuzzleDay, measureAllocs bool) []PartResult {
+	var results []PartResult
+	for part := 1; part <= 2; part++ {
+		if _, ok := utils.RegisteredSolution(pd.Year, pd.Day, part); ok {
+			results = append(results, runPart(pd.Year, pd.Day, part, measureAllocs))
+		}
+	}
+	return results
+}
+
+// RunDays runs several days, with up to parallel days running at once.  The results are returned in the same
+// order as the days.  Allocations are not measured if more than one day runs at a time.
+func RunDays(days []utils.PuzzleDay, parallel int) []PartResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+	measureAllocs := parallel == 1 || len(days) <= 1
+	dayResults := make([][]PartResult, len(days))
+	sem := make(chan struct{}, parallel)
+	wg := sync.WaitGroup{}
+	for i, pd := range days {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			dayResults[i] = runDay(pd, measureAllocs)
+			<-sem
+		}()
+	}
+	wg.Wait()
+	var results []PartResult
+	for _, dr := range dayResults {
+		results = append(results, dr...)
+	}
+	return results
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	utils "github.com/ghjm/advent_utils"
+)
+
+func init() {
+	utils.Register(1000, 1, 1, func(string) (any, error) {
+		return 42, nil
+	})
+	utils.Register(1000, 1, 2, func(string) (any, error) {
+		var m map[string]int
+		m["boom"]++
+		return nil, nil
+	})
+	utils.Register(1000, 2, 1, func(string) (any, error) {
+		return make([]byte, 1<<16), nil
+	})
+}
+
+func TestRunPartRecoversPanic(t *testing.T) {
+	r := RunPart(1000, 1, 2)
+	if !strings.HasPrefix(r.Error, "panic: ") {
+		t.Errorf("expected a panic to be reported, got %+v", r)
+	}
+	r = RunPart(1000, 1, 1)
+	if r.Error != "" || r.Answer != "42" {
+		t.Errorf("expected answer 42, got %+v", r)
+	}
+}
+
+func TestRunDaysAllocs(t *testing.T) {
+	days := []utils.PuzzleDay{{Year: 1000, Day: 1}, {Year: 1000, Day: 2}}
+	for _, r := range RunDays(days, 1) {
+		if !r.AllocsMeasured {
+			t.Errorf("expected allocations to be measured when running serially: %+v", r)
+		}
+	}
+	results := RunDays(days, 2)
+	for _, r := range results {
+		if r.AllocsMeasured || r.Allocs != 0 || r.Bytes != 0 {
+			t.Errorf("expected no allocations to be reported when running in parallel: %+v", r)
+		}
+	}
+	var buf bytes.Buffer
+	err := WriteTable(&buf, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if f := strings.Fields(lines[1]); len(f) != 7 || f[5] != "-" || f[6] != "-" {
+		t.Errorf("expected blank allocation columns, got %q", lines[1])
+	}
+	if !strings.Contains(buf.String(), "use -j 1") {
+		t.Errorf("expected a note on how to see allocations, got %q", buf.String())
+	}
+	buf.Reset()
+	err = WriteTable(&buf, RunDays(days, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "use -j 1") {
+		t.Errorf("expected no note when allocations were measured")
+	}
+	if r := RunDays(days[:1], 8); !r[0].AllocsMeasured {
+		t.Errorf("expected allocations to be measured when only one day runs")
+	}
+}
+
+func TestSelectDays(t *testing.T) {
+	tests := []struct {
+		args    []string
+		all     bool
+		latest  bool
+		wantErr bool
+	}{
+		{[]string{"1000/1"}, false, false, false},
+		{[]string{"1000"}, false, false, false},
+		{nil, true, false, false},
+		{nil, false, true, false},
+		{[]string{"1000/1"}, false, true, true},
+		{[]string{"1000/1"}, true, false, true},
+		{nil, true, true, true},
+		{[]string{"1000/9"}, false, false, true},
+		{[]string{"x"}, false, false, true},
+	}
+	for _, tt := range tests {
+		_, err := selectDays(tt.args, tt.all, tt.latest)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("selectDays(%v, %v, %v): unexpected error %v", tt.args, tt.all, tt.latest, err)
+		}
+	}
+	days, err := selectDays([]string{"1000"}, false, false)
+	if err != nil || len(days) != 2 {
+		t.Errorf("expected both days of 1000, got %v, %v", days, err)
+	}
+}
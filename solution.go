@@ -0,0 +1,5 @@
+package utils
+
+// Solution solves one part of a puzzle.  It is given the name of the input to read with OpenInputFile or the
+// functions built on it, and returns the answer.
+type Solution func(input string) (any, error)